@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/notifier"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+var backCmd = &cobra.Command{
+	Use:   "back",
+	Short: "End the current break early",
+	Long: `End the active break started with 'tasklog break' and:
+- Clear your Slack status
+- Resume Slack notifications and presence paused for the break
+- Edit the break message to show how long you were away (or post a "back" message)
+- Record the actual end time so summaries show the real break length
+
+Example:
+  tasklog break lunch
+  tasklog back` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runBack,
+}
+
+func init() {
+	rootCmd.AddCommand(backCmd)
+}
+
+func runBack(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	active, err := store.GetActiveBreak(now)
+	if err != nil {
+		return err
+	}
+	if active == nil {
+		fmt.Println("ℹ️  You're not on a break")
+		return nil
+	}
+
+	breakEntry, _ := cfg.GetBreak(active.Name)
+	return endBreak(cfg, store, active, breakEntry, now)
+}
+
+// endBreak records the end of a break and undoes what starting it did: Slack notifications and presence are
+// resumed (breakEntry may be nil for breaks no longer in the config), the status is cleared or goes back to
+// the running task, and the break message is edited
+func endBreak(cfg *config.Config, store *storage.Storage, active *storage.Break, breakEntry *config.BreakEntry, now time.Time) error {
+	if err := store.EndBreak(active.ID, now); err != nil {
+		return err
+	}
+
+	breakLength := timeparse.Format(int(now.Sub(active.StartedAt).Seconds()))
+	fmt.Printf("✅ Back from %s break after %s\n", active.Name, breakLength)
+
+	// Resume notifications and presence paused for the break
+	if breakEntry != nil {
+		resumeSlack(cfg, breakEntry.Snooze, breakEntry.Away)
+	}
+
+	n, err := notifier.New(cfg)
+	if err != nil {
+		log.Debug().Err(err).Msg("No notifier configured, skipping status update")
+		return nil
+	}
+
+	// Go back to the running task's status, if there is one
+	statusCleared := false
+	if n.Supports(notifier.FeatureStatus) {
+		timer, err := store.GetActiveTimer()
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to check for a running timer")
+		}
+		if timer != nil && cfg.Slack.TaskStatus.Enabled {
+			err = n.SetStatus(taskStatusText(timer.IssueKey, timer.IssueSummary), cfg.Slack.TaskStatus.Emoji, time.Time{})
+		} else {
+			err = n.ClearStatus()
+		}
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to clear %s status", n.Name())
+		} else {
+			statusCleared = true
+		}
+	}
+
+	// Edit the message that announced the break, or post a new one if that isn't possible
+	messageDone := false
+	messageVerb := "posted"
+	if active.MessageTS != "" && n.Supports(notifier.FeatureUpdate) {
+		emoji := defaultBreakEmoji
+		if breakEntry != nil && breakEntry.Emoji != "" {
+			emoji = breakEntry.Emoji
+		}
+		message := fmt.Sprintf("✅ Took a %s *%s break* — back after %s at *%s*", emoji, active.Name, breakLength, now.Format("3:04 PM"))
+		if err := n.UpdateMessage(active.MessageTS, message); err != nil {
+			log.Error().Err(err).Msgf("Failed to update break message in %s, posting a new one", n.Name())
+		} else {
+			messageDone = true
+			messageVerb = "updated"
+		}
+	}
+
+	if !messageDone {
+		threadID := ""
+		if cfg.Slack.DailyThread && n.Supports(notifier.FeatureThread) {
+			if threadID, err = store.GetDailyThread(now, cfg.Slack.ChannelID); err != nil {
+				log.Warn().Err(err).Msg("Failed to look up today's break thread")
+			}
+		}
+		message := fmt.Sprintf("👋 Back from *%s break*", active.Name)
+		if _, err := n.PostMessage(message, threadID); err != nil {
+			log.Error().Err(err).Msgf("Failed to post message to %s", n.Name())
+		} else {
+			messageDone = true
+		}
+	}
+
+	fmt.Println(notificationSummary(n, "cleared", statusCleared, messageVerb, messageDone))
+
+	return nil
+}
+
+// restoreExpiredBreaks ends breaks that ran past their planned end without 'tasklog back'. Slack presence
+// doesn't expire, so it is set back to auto if one of them set it to away, unless a running break still wants it.
+// Problems are only logged so they never get in the way of the command being run
+func restoreExpiredBreaks(cfg *config.Config, now time.Time) {
+	if cfg.Slack.UserToken == "" || !hasAwayBreak(cfg) {
+		return
+	}
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to open local database, not checking for expired breaks")
+		return
+	}
+	defer store.Close()
+
+	expired, err := store.GetExpiredBreaks(now)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check for expired breaks")
+		return
+	}
+
+	away := false
+	for _, b := range expired {
+		if err := store.EndBreak(b.ID, b.PlannedEnd); err != nil {
+			log.Warn().Err(err).Msg("Failed to end expired break")
+			return
+		}
+		if breakEntry, found := cfg.GetBreak(b.Name); found && breakEntry.Away {
+			away = true
+		}
+	}
+	if !away {
+		return
+	}
+
+	active, err := store.GetActiveBreak(now)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check for an active break")
+		return
+	}
+	if active != nil {
+		if breakEntry, found := cfg.GetBreak(active.Name); found && breakEntry.Away {
+			return
+		}
+	}
+	resumeSlack(cfg, false, true)
+}
+
+// hasAwayBreak reports whether any configured break sets Slack presence to away
+func hasAwayBreak(cfg *config.Config) bool {
+	for _, breakEntry := range cfg.Slack.Breaks {
+		if breakEntry.Away {
+			return true
+		}
+	}
+	return false
+}
@@ -5,7 +5,8 @@ import (
 	"time"
 
 	"tasklog/internal/config"
-	"tasklog/internal/slack"
+	"tasklog/internal/notifier"
+	"tasklog/internal/storage"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -18,8 +19,13 @@ var breakCmd = &cobra.Command{
 	Short: "Register a break and update Slack status",
 	Long: `Register a break (e.g., lunch, prayer, coffee) and automatically:
 - Update your Slack status with break emoji
-- Post a message in the configured Slack channel
+- Post a message in the configured Slack channel (or Mattermost/Teams webhook)
 - Set status to expire after break duration
+- Pause Slack notifications and set presence to away (with snooze/away in the break config)
+- Record the break so it shows up in 'tasklog summary'
+
+Run 'tasklog back' to end a break early. If the break runs out instead, the next tasklog
+command sets your presence back to auto.
 
 Example:
   tasklog break lunch
@@ -36,6 +42,9 @@ func init() {
 }
 
 func runBreak(cmd *cobra.Command, args []string) {
+	// Offer to upgrade outdated config files before loading
+	offerConfigMigration()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -77,97 +86,186 @@ func runBreak(cmd *cobra.Command, args []string) {
 			Msg("Break not found in configuration. Please add it to your config.yaml")
 	}
 
-	// Check if Slack is configured
-	if cfg.Slack.UserToken == "" || cfg.Slack.ChannelID == "" {
-		log.Warn().Msg("Slack not configured. Break registered but Slack status not updated.")
-		fmt.Printf("⏸️  Taking a %s break for %d minutes\n", breakName, breakEntry.Duration)
-		return
-	}
+	startBreak(cfg, breakEntry)
+}
 
-	// Create Slack client
-	slackClient := slack.NewClient(cfg.Slack.UserToken, cfg.Slack.ChannelID)
+// startBreak records a break, updates the Slack status and posts a message to the channel
+// Slack failures are reported but don't stop the break from being registered. It returns the recorded
+// break, or nil if it couldn't be recorded
+func startBreak(cfg *config.Config, breakEntry *config.BreakEntry) *storage.Break {
+	breakName := breakEntry.Name
 
 	// Calculate return time
-	returnTime := time.Now().Add(time.Duration(breakEntry.Duration) * time.Minute)
+	startTime := time.Now()
+	returnTime := startTime.Add(time.Duration(breakEntry.Duration) * time.Minute)
+
+	// Record the break locally; Slack updates still go ahead if this fails
+	var recorded *storage.Break
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to open local database, break not recorded")
+	} else {
+		defer store.Close()
+		if recorded, err = recordBreak(store, breakName, startTime, returnTime); err != nil {
+			log.Warn().Err(err).Msg("Failed to record break in local database")
+		}
+	}
+
+	// Pause notifications and show as away if the break asks for it
+	pauseSlack(cfg, returnTime, breakEntry.Snooze, breakEntry.Away)
+
+	// Pick the configured notifier (Slack by default)
+	n, err := notifier.New(cfg)
+	if err != nil {
+		log.Warn().Err(err).Msg("Break registered but nobody was notified")
+		fmt.Printf("⏸️  Taking a %s break for %d minutes\n", breakName, breakEntry.Duration)
+		return recorded
+	}
 
 	// Track what succeeded
 	statusUpdated := false
 	messagePosted := false
 
-	// Set Slack status with 5 extra minutes buffer for auto-clear
 	statusText := fmt.Sprintf("On %s break (back at %s)", breakName, returnTime.Format("3:04 PM"))
 	statusEmoji := breakEntry.Emoji
 	if statusEmoji == "" {
 		statusEmoji = defaultBreakEmoji
 	}
 
-	// Add 5 minutes buffer to auto-clear the status
-	statusExpirationMinutes := breakEntry.Duration + 5
+	if n.Supports(notifier.FeatureStatus) {
+		// Add 5 minutes buffer to auto-clear the status
+		statusExpiration := returnTime.Add(5 * time.Minute)
 
-	err = slackClient.SetStatus(statusText, statusEmoji, statusExpirationMinutes)
-	if err != nil {
-		log.Error().Err(err).Str("emoji", statusEmoji).Msg("Failed to update Slack status")
-
-		// If the error is about invalid emoji and we're not already using the default, retry with default
-		if statusEmoji != defaultBreakEmoji &&
-			(err.Error() == "slack API error: profile_status_set_failed_not_valid_emoji" ||
-				err.Error() == "slack API error: profile_status_set_failed_not_emoji_syntax" ||
-				err.Error() == "slack API error: invalid_emoji") {
-			log.Warn().Msg("Invalid emoji detected, retrying with default emoji")
-			err = slackClient.SetStatus(statusText, defaultBreakEmoji, statusExpirationMinutes)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to update Slack status with default emoji")
-			} else {
-				log.Info().
-					Str("status", statusText).
-					Str("emoji", defaultBreakEmoji).
-					Int("expiration_minutes", statusExpirationMinutes).
-					Msg("Slack status updated with default emoji")
-				statusUpdated = true
+		err := n.SetStatus(statusText, statusEmoji, statusExpiration)
+		if err != nil {
+			log.Error().Err(err).Str("emoji", statusEmoji).Msgf("Failed to update %s status", n.Name())
+
+			// If the error is about invalid emoji and we're not already using the default, retry with default
+			if statusEmoji != defaultBreakEmoji &&
+				(err.Error() == "slack API error: profile_status_set_failed_not_valid_emoji" ||
+					err.Error() == "slack API error: profile_status_set_failed_not_emoji_syntax" ||
+					err.Error() == "slack API error: invalid_emoji") {
+				log.Warn().Msg("Invalid emoji detected, retrying with default emoji")
+				err = n.SetStatus(statusText, defaultBreakEmoji, statusExpiration)
+				if err != nil {
+					log.Error().Err(err).Msgf("Failed to update %s status with default emoji", n.Name())
+				} else {
+					log.Info().
+						Str("status", statusText).
+						Str("emoji", defaultBreakEmoji).
+						Time("expiration", statusExpiration).
+						Msgf("%s status updated with default emoji", n.Name())
+					statusUpdated = true
+				}
 			}
+		} else {
+			log.Info().
+				Str("status", statusText).
+				Str("emoji", statusEmoji).
+				Time("expiration", statusExpiration).
+				Msgf("%s status updated", n.Name())
+			statusUpdated = true
 		}
-	} else {
-		log.Info().
-			Str("status", statusText).
-			Str("emoji", statusEmoji).
-			Int("expiration_minutes", statusExpirationMinutes).
-			Msg("Slack status updated")
-		statusUpdated = true
 	}
 
-	// Post message to channel
-	emojiForMessage := breakEntry.Emoji
-	if emojiForMessage == "" {
-		emojiForMessage = defaultBreakEmoji
+	// Post message to channel, or to today's break thread
+	threadID := ""
+	if cfg.Slack.DailyThread && n.Supports(notifier.FeatureThread) && store != nil {
+		threadID, err = dailyBreakThread(store, n, cfg.Slack.ChannelID, startTime)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to start today's break thread, posting to the channel instead")
+		}
 	}
+
 	message := fmt.Sprintf("🔔 Taking a %s *%s break* — Back in %d minutes at *%s*",
-		emojiForMessage,
+		statusEmoji,
 		breakName,
 		breakEntry.Duration,
 		returnTime.Format("3:04 PM"))
 
-	err = slackClient.PostMessage(message)
+	messageID, err := n.PostMessage(message, threadID)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to post message to Slack")
+		log.Error().Err(err).Msgf("Failed to post message to %s", n.Name())
 	} else {
 		log.Info().
-			Str("channel", cfg.Slack.ChannelID).
 			Str("message", message).
-			Msg("Message posted to Slack")
+			Str("thread", threadID).
+			Msgf("Message posted to %s", n.Name())
 		messagePosted = true
+
+		// Remember the message so 'tasklog back' can edit it
+		if recorded != nil && messageID != "" {
+			if err := store.SetBreakMessage(recorded.ID, messageID); err != nil {
+				log.Warn().Err(err).Msg("Failed to save break message")
+			}
+		}
 	}
 
 	// Display success message with accurate status
 	fmt.Printf("✅ Break registered: %s (%d minutes)\n", breakName, breakEntry.Duration)
 	fmt.Printf("📅 Return time: %s\n", returnTime.Format("3:04 PM"))
+	fmt.Println(notificationSummary(n, "set", statusUpdated, "posted", messagePosted))
+	return recorded
+}
 
-	if statusUpdated && messagePosted {
-		fmt.Printf("💬 Slack updated: Status set and message posted\n")
-	} else if messagePosted {
-		fmt.Printf("💬 Slack updated: Message posted (status not updated)\n")
-	} else if statusUpdated {
-		fmt.Printf("💬 Slack updated: Status set (message failed)\n")
-	} else {
-		fmt.Printf("⚠️  Slack update failed\n")
+// notificationSummary describes what a notifier managed to do, leaving out features it doesn't support
+// statusVerb and messageVerb say what happened to the status and message (e.g., "set" and "posted")
+func notificationSummary(n notifier.Notifier, statusVerb string, statusDone bool, messageVerb string, messageDone bool) string {
+	if !n.Supports(notifier.FeatureStatus) {
+		if messageDone {
+			return fmt.Sprintf("💬 %s updated: Message %s", n.Name(), messageVerb)
+		}
+		return fmt.Sprintf("⚠️  %s update failed", n.Name())
+	}
+
+	switch {
+	case statusDone && messageDone:
+		return fmt.Sprintf("💬 %s updated: Status %s and message %s", n.Name(), statusVerb, messageVerb)
+	case messageDone:
+		return fmt.Sprintf("💬 %s updated: Message %s (status not %s)", n.Name(), messageVerb, statusVerb)
+	case statusDone:
+		return fmt.Sprintf("💬 %s updated: Status %s (message failed)", n.Name(), statusVerb)
+	}
+	return fmt.Sprintf("⚠️  %s update failed", n.Name())
+}
+
+// dailyBreakThread returns today's break thread in the channel, starting it if this is the first break of the day
+func dailyBreakThread(store *storage.Storage, n notifier.Notifier, channelID string, now time.Time) (string, error) {
+	threadID, err := store.GetDailyThread(now, channelID)
+	if err != nil || threadID != "" {
+		return threadID, err
+	}
+
+	threadID, err = n.PostMessage(fmt.Sprintf("🗓️ Breaks for *%s*", now.Format("Monday, January 2")), "")
+	if err != nil {
+		return "", err
+	}
+	if err := store.SetDailyThread(now, channelID, threadID); err != nil {
+		return "", err
+	}
+	return threadID, nil
+}
+
+// recordBreak stores a new break, ending any break that is still active
+func recordBreak(store *storage.Storage, name string, start, plannedEnd time.Time) (*storage.Break, error) {
+	active, err := store.GetActiveBreak(start)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		log.Debug().Str("name", active.Name).Msg("Ending previous break before starting a new one")
+		if err := store.EndBreak(active.ID, start); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &storage.Break{
+		Name:       name,
+		StartedAt:  start,
+		PlannedEnd: plannedEnd,
+	}
+	if err := store.AddBreak(b); err != nil {
+		return nil, err
 	}
+	return b, nil
 }
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tasklog/internal/notifier"
+	"tasklog/internal/storage"
+)
+
+// fakeNotifier records posted messages and hands out sequential message IDs
+type fakeNotifier struct {
+	features notifier.Feature
+	posted   []string
+}
+
+func (f *fakeNotifier) Name() string { return "Slack" }
+
+func (f *fakeNotifier) Supports(feature notifier.Feature) bool { return f.features&feature == feature }
+
+func (f *fakeNotifier) SetStatus(text, emoji string, until time.Time) error { return nil }
+
+func (f *fakeNotifier) ClearStatus() error { return nil }
+
+func (f *fakeNotifier) PostMessage(text, threadID string) (string, error) {
+	f.posted = append(f.posted, text)
+	return fmt.Sprintf("ts-%d", len(f.posted)), nil
+}
+
+func (f *fakeNotifier) UpdateMessage(id, text string) error { return nil }
+
+func TestDailyBreakThread(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	n := &fakeNotifier{features: notifier.FeatureMessage | notifier.FeatureThread}
+	morning := time.Date(2024, 4, 5, 10, 0, 0, 0, time.Local)
+
+	first, err := dailyBreakThread(store, n, "C123", morning)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := dailyBreakThread(store, n, "C123", morning.Add(5*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != "ts-1" || second != first {
+		t.Errorf("expected one thread for the day, got %q and %q", first, second)
+	}
+	if len(n.posted) != 1 || n.posted[0] != "🗓️ Breaks for *Friday, April 5*" {
+		t.Errorf("expected a single thread header, got %v", n.posted)
+	}
+
+	next, err := dailyBreakThread(store, n, "C123", morning.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next != "ts-2" {
+		t.Errorf("expected a new thread the next day, got %q", next)
+	}
+}
+
+func TestNotificationSummary(t *testing.T) {
+	slackUser := &fakeNotifier{features: notifier.FeatureStatus | notifier.FeatureMessage | notifier.FeatureUpdate}
+	webhook := &fakeNotifier{features: notifier.FeatureMessage}
+
+	tests := []struct {
+		name        string
+		n           notifier.Notifier
+		statusDone  bool
+		messageVerb string
+		messageDone bool
+		expected    string
+	}{
+		{name: "status and message", n: slackUser, statusDone: true, messageVerb: "posted", messageDone: true, expected: "💬 Slack updated: Status set and message posted"},
+		{name: "message updated", n: slackUser, statusDone: true, messageVerb: "updated", messageDone: true, expected: "💬 Slack updated: Status set and message updated"},
+		{name: "status failed", n: slackUser, messageVerb: "posted", messageDone: true, expected: "💬 Slack updated: Message posted (status not set)"},
+		{name: "message failed", n: slackUser, statusDone: true, messageVerb: "posted", expected: "💬 Slack updated: Status set (message failed)"},
+		{name: "everything failed", n: slackUser, messageVerb: "posted", expected: "⚠️  Slack update failed"},
+		{name: "message only backend", n: webhook, messageVerb: "posted", messageDone: true, expected: "💬 Slack updated: Message posted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notificationSummary(tt.n, "set", tt.statusDone, tt.messageVerb, tt.messageDone); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"tasklog/internal/checks"
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+
+	"github.com/rs/zerolog/log"
+)
+
+// checkWorklogs runs the configured checks on new worklogs against the ones already logged on those days
+// Warnings are printed; an error is returned when a check blocks
+func checkWorklogs(cfg *config.Config, store *storage.Storage, entries []checks.Worklog, now time.Time) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	from, to := entries[0].Started, entries[0].Started
+	for _, entry := range entries[1:] {
+		if entry.Started.Before(from) {
+			from = entry.Started
+		}
+		if entry.Started.After(to) {
+			to = entry.Started
+		}
+	}
+	from = startOfDay(from)
+	to = startOfDay(to).AddDate(0, 0, 1)
+
+	existing, err := existingWorklogs(cfg, store, from, to)
+	if err != nil {
+		return err
+	}
+
+	problems := checks.NewPolicy(cfg.Checks).Check(entries, existing, now)
+	for _, problem := range problems {
+		if problem.Level == checks.LevelBlock {
+			fmt.Printf("❌ %s\n", problem.Message)
+		} else {
+			fmt.Printf("⚠️  %s\n", problem.Message)
+		}
+	}
+	if checks.Blocked(problems) {
+		return fmt.Errorf("worklog blocked by checks (change the levels in the checks config section)")
+	}
+	return nil
+}
+
+// existingWorklogs returns the work logged in [from, to): local entries, plus Tempo worklogs when checks.tempo is set
+func existingWorklogs(cfg *config.Config, store *storage.Storage, from, to time.Time) ([]checks.Worklog, error) {
+	entries, err := store.GetEntriesBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local entries: %w", err)
+	}
+
+	var worklogs []checks.Worklog
+	logged := make(map[string]bool) // Jira and Tempo worklog IDs of the local entries
+	for i := range entries {
+		entry := &entries[i]
+		worklogs = append(worklogs, entryWorklog(entry))
+		if entry.JiraWorklogID != nil {
+			logged["jira:"+*entry.JiraWorklogID] = true
+		}
+		if entry.TempoWorklogID != nil {
+			logged["tempo:"+*entry.TempoWorklogID] = true
+		}
+	}
+
+	if cfg.Checks.Tempo && cfg.UsesTempo() {
+		tempoWorklogs, err := fetchTempoWorklogs(cfg, from, to, logged)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to fetch Tempo worklogs for checks")
+			fmt.Printf("⚠️  Checked against local entries only: %v\n", err)
+		}
+		worklogs = append(worklogs, tempoWorklogs...)
+	}
+	return worklogs, nil
+}
+
+// fetchTempoWorklogs returns the current user's Tempo worklogs in [from, to), leaving out the ones logged by tasklog:
+// the local entries have those, and know whether their start is exact. Other Tempo worklogs count as exact
+func fetchTempoWorklogs(cfg *config.Config, from, to time.Time, logged map[string]bool) ([]checks.Worklog, error) {
+	jiraClient := newJiraClient(cfg)
+	currentUser, err := jiraClient.GetCurrentUser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	// Tempo's range is inclusive of both dates
+	tempoWorklogs, err := newTempoClient(cfg).GetWorklogs(from, to.AddDate(0, 0, -1), currentUser.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var worklogs []checks.Worklog
+	for _, wl := range tempoWorklogs {
+		if logged["jira:"+strconv.Itoa(wl.JiraWorklogID)] || logged["tempo:"+strconv.Itoa(wl.TempoWorklogID)] {
+			continue
+		}
+		started, err := time.ParseInLocation("2006-01-02 15:04:05", wl.StartDate+" "+wl.StartTime, time.Local)
+		if err != nil {
+			log.Debug().Err(err).Int("worklog", wl.TempoWorklogID).Msg("Skipping Tempo worklog with unparsable start")
+			continue
+		}
+		worklogs = append(worklogs, checks.Worklog{IssueKey: wl.IssueKey, Started: started, Seconds: wl.TimeSpentSeconds, Exact: true})
+	}
+	return worklogs, nil
+}
+
+// entryWorklog converts a time entry for checking
+func entryWorklog(entry *storage.TimeEntry) checks.Worklog {
+	return checks.Worklog{IssueKey: entry.IssueKey, Started: entry.Started, Seconds: entry.TimeSpentSeconds, Exact: entry.StartExact}
+}
+
+// entryWorklogs converts time entries for checking
+func entryWorklogs(entries []*storage.TimeEntry) []checks.Worklog {
+	worklogs := make([]checks.Worklog, 0, len(entries))
+	for _, entry := range entries {
+		worklogs = append(worklogs, entryWorklog(entry))
+	}
+	return worklogs
+}
+
+// startOfDay returns midnight of the given day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
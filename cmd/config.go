@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
 
 	"tasklog/internal/config"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
 
 	"github.com/spf13/cobra"
 )
@@ -32,8 +36,11 @@ var configShowCmd = &cobra.Command{
 	Short: "Display current configuration",
 	Long: `Displays your current configuration file.
 
-This shows the raw YAML content of your config file at ~/.tasklog/config.yaml
-(or the path specified by TASKLOG_CONFIG environment variable).`,
+This shows the YAML content of your config file at ~/.tasklog/config.yaml
+(or the path specified by TASKLOG_CONFIG environment variable).
+
+Plaintext tokens are redacted. Secret references such as env:JIRA_TOKEN,
+file:/run/secrets/jira or cmd:pass show jira are shown as written.`,
 	RunE: runConfigShow,
 }
 
@@ -47,11 +54,120 @@ or identify deprecated fields that might no longer be needed.`,
 	RunE: runConfigCompare,
 }
 
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a configuration value",
+	Long: `Prints the value at a dotted config key. Plaintext tokens are redacted.
+
+Examples:
+  tasklog config get jira.project_key
+  tasklog config get jira.task_statuses
+  tasklog config get jira.shortcuts.0.time`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value",
+	Long: `Sets the value at a dotted config key, keeping comments in your config file intact.
+
+Values for list, number and boolean fields are parsed as YAML. The edited
+configuration is validated before it is written.
+
+Examples:
+  tasklog config set jira.project_key PROJ
+  tasklog config set jira.task_statuses '["In Progress","Review"]'
+  tasklog config set tempo.enabled true
+  tasklog config set jira.api_token env:JIRA_TOKEN`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value",
+	Long: `Removes the value at a dotted config key. The edited configuration is
+validated before it is written.
+
+Example:
+  tasklog config unset labels.allowed_labels`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigUnset,
+}
+
+var (
+	migrateDryRun bool
+	migrateYes    bool
+)
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade your config file to the latest schema version",
+	Long: `Upgrades your config file to the current schema version by applying each
+registered migration step in order (e.g., moving root 'shortcuts' under 'jira').
+
+The original file is backed up next to your config before any change is written,
+and comments in your config are kept.`,
+	RunE: runConfigMigrate,
+}
+
+var configShortcutCmd = &cobra.Command{
+	Use:   "shortcut",
+	Short: "Manage jira shortcuts",
+	Long:  `Add or remove shortcuts under jira.shortcuts without editing the config file by hand.`,
+}
+
+var (
+	shortcutTask       string
+	shortcutTime       string
+	shortcutLabel      string
+	shortcutTransition string
+)
+
+var configShortcutAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a shortcut",
+	Long: `Adds a shortcut to jira.shortcuts.
+
+Example:
+  tasklog config shortcut add daily --task PROJ-123 --time 30m --label meeting`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigShortcutAdd,
+}
+
+var configShortcutRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a shortcut",
+	Long: `Removes a shortcut from jira.shortcuts.
+
+Example:
+  tasklog config shortcut remove daily`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigShortcutRemove,
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configExampleCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configCompareCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configCmd.AddCommand(configShortcutCmd)
+	configShortcutCmd.AddCommand(configShortcutAddCmd)
+	configShortcutCmd.AddCommand(configShortcutRemoveCmd)
+
+	configShortcutAddCmd.Flags().StringVarP(&shortcutTask, "task", "t", "", "Task key (e.g., PROJ-123)")
+	configShortcutAddCmd.Flags().StringVarP(&shortcutTime, "time", "d", "", "Predefined time (e.g., 30m)")
+	configShortcutAddCmd.Flags().StringVarP(&shortcutLabel, "label", "l", "", "Work log label")
+	configShortcutAddCmd.Flags().StringVar(&shortcutTransition, "transition", "", "Transition to make after logging (e.g., Done)")
+	_ = configShortcutAddCmd.MarkFlagRequired("task")
+
+	configMigrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show what would change without writing")
+	configMigrateCmd.Flags().BoolVarP(&migrateYes, "yes", "y", false, "Apply without asking for confirmation")
 }
 
 func runConfigExample(cmd *cobra.Command, args []string) error {
@@ -88,9 +204,15 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	// Redact plaintext secrets before printing
+	redacted, err := config.RedactSecrets(data)
+	if err != nil {
+		return fmt.Errorf("failed to redact config file: %w", err)
+	}
+
 	// Print config path and content
 	fmt.Printf("# Configuration file: %s\n\n", configPath)
-	fmt.Print(string(data))
+	fmt.Print(string(redacted))
 
 	return nil
 }
@@ -124,3 +246,227 @@ func runConfigCompare(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	doc, err := config.LoadDocument()
+	if err != nil {
+		return err
+	}
+
+	// Re-parse a redacted copy so plaintext tokens are never printed
+	data, err := doc.Bytes()
+	if err != nil {
+		return err
+	}
+	redacted, err := config.RedactSecrets(data)
+	if err != nil {
+		return fmt.Errorf("failed to redact config file: %w", err)
+	}
+	redactedDoc, err := config.ParseDocument(redacted)
+	if err != nil {
+		return err
+	}
+
+	value, err := redactedDoc.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+
+	doc, err := config.LoadDocument()
+	if err != nil {
+		return err
+	}
+
+	if err := doc.Set(key, value); err != nil {
+		return err
+	}
+
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("config not saved: %w", err)
+	}
+
+	fmt.Printf("✓ Set %s in %s\n", key, doc.Path())
+	if slices.Contains(config.SecretFields, key) && !config.IsSecretReference(value) {
+		fmt.Println("💡 Consider a secret reference instead of a plaintext token (e.g., env:JIRA_TOKEN)")
+	}
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	doc, err := config.LoadDocument()
+	if err != nil {
+		return err
+	}
+
+	if err := doc.Unset(args[0]); err != nil {
+		return err
+	}
+
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("config not saved: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %s from %s\n", args[0], doc.Path())
+	return nil
+}
+
+func runConfigShortcutAdd(cmd *cobra.Command, args []string) error {
+	shortcut := config.ShortcutEntry{
+		Name:       args[0],
+		Task:       shortcutTask,
+		Time:       shortcutTime,
+		Label:      shortcutLabel,
+		Transition: shortcutTransition,
+	}
+
+	if shortcut.Time != "" {
+		if err := timeparse.Validate(shortcut.Time); err != nil {
+			return fmt.Errorf("invalid time for shortcut: %w", err)
+		}
+	}
+
+	doc, err := config.LoadDocument()
+	if err != nil {
+		return err
+	}
+
+	// Labels must respect the allowed labels list, same as when logging
+	if shortcut.Label != "" {
+		cfg, err := doc.Config()
+		if err != nil {
+			return err
+		}
+		if !cfg.IsLabelAllowed(shortcut.Label) {
+			return fmt.Errorf("label '%s' is not in the allowed labels list", shortcut.Label)
+		}
+	}
+
+	if err := doc.AddShortcut(shortcut); err != nil {
+		return err
+	}
+
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("config not saved: %w", err)
+	}
+
+	fmt.Printf("✓ Shortcut '%s' added (%s)\n", shortcut.Name, shortcut.Task)
+	fmt.Printf("Run: tasklog log %s\n", shortcut.Name)
+	return nil
+}
+
+func runConfigShortcutRemove(cmd *cobra.Command, args []string) error {
+	doc, err := config.LoadDocument()
+	if err != nil {
+		return err
+	}
+
+	if err := doc.RemoveShortcut(args[0]); err != nil {
+		return err
+	}
+
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("config not saved: %w", err)
+	}
+
+	fmt.Printf("✓ Shortcut '%s' removed\n", args[0])
+	return nil
+}
+
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	doc, err := config.LoadDocument()
+	if err != nil {
+		return err
+	}
+
+	if !doc.NeedsMigration() {
+		if doc.Version() > config.CurrentConfigVersion {
+			return fmt.Errorf("config version %d is newer than supported version %d, please upgrade tasklog", doc.Version(), config.CurrentConfigVersion)
+		}
+		fmt.Printf("✓ Config is already at the latest version (%d)\n", config.CurrentConfigVersion)
+		return nil
+	}
+
+	if migrateDryRun {
+		result, err := doc.Migrate()
+		if err != nil {
+			return err
+		}
+		printMigrationResult(os.Stdout, result)
+		fmt.Println("\nDry run: no changes written.")
+		return nil
+	}
+
+	return migrateConfigDocument(os.Stdout, doc, !migrateYes)
+}
+
+// offerConfigMigration migrates an outdated config file after asking the user
+// Errors are reported but never block the command that loads the config. Everything goes to stderr, so the
+// command's own output stays clean, and without a terminal to ask on only a hint is printed
+func offerConfigMigration() {
+	doc, err := config.LoadDocument()
+	if err != nil || !doc.NeedsMigration() {
+		return
+	}
+
+	if !ui.IsInteractive() {
+		fmt.Fprintf(os.Stderr, "⚙️  Your config file uses schema version %d (latest is %d); run 'tasklog config migrate' to upgrade it\n", doc.Version(), config.CurrentConfigVersion)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\n⚙️  Your config file uses schema version %d (latest is %d)\n", doc.Version(), config.CurrentConfigVersion)
+	if err := migrateConfigDocument(os.Stderr, doc, true); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Config migration failed: %v\n", err)
+		fmt.Fprintf(os.Stderr, "   Run 'tasklog config migrate --dry-run' to see the pending changes\n\n")
+	}
+}
+
+// migrateConfigDocument migrates, backs up and saves a config document, reporting to out
+func migrateConfigDocument(out io.Writer, doc *config.Document, confirm bool) error {
+	fromVersion := doc.Version()
+
+	if confirm {
+		fmt.Fprintln(out, "Pending config migrations:")
+		for _, migration := range doc.PendingMigrations() {
+			fmt.Fprintf(out, "  • v%d → v%d: %s\n", migration.From, migration.From+1, migration.Description)
+		}
+		if !confirmActionOn(out, "Migrate your config file now? A backup will be created") {
+			fmt.Fprintln(out, "Migration skipped. Run 'tasklog config migrate' when ready.")
+			fmt.Fprintln(out)
+			return nil
+		}
+	}
+
+	result, err := doc.Migrate()
+	if err != nil {
+		return err
+	}
+
+	backupPath, err := doc.Backup()
+	if err != nil {
+		return err
+	}
+
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("config not saved (original kept at %s): %w", backupPath, err)
+	}
+
+	fmt.Fprintf(out, "✓ Config migrated from version %d to %d\n", fromVersion, result.ToVersion)
+	printMigrationResult(out, result)
+	fmt.Fprintf(out, "Backup saved at: %s\n\n", backupPath)
+	return nil
+}
+
+// printMigrationResult lists the changes made by a migration
+func printMigrationResult(out io.Writer, result *config.MigrationResult) {
+	fmt.Fprintln(out, "Changes:")
+	for _, change := range result.Changes {
+		fmt.Fprintf(out, "  • %s\n", change)
+	}
+}
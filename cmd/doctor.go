@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/doctor"
+	"tasklog/internal/github"
+	"tasklog/internal/jira"
+	"tasklog/internal/slack"
+	"tasklog/internal/storage"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check configuration, connectivity and local database health",
+	Long: `Runs a series of health checks and prints a pass/fail report with a fix hint for each problem:
+
+- Config file validity and schema version
+- Jira reachability, authentication, project access and time tracking
+- Clock skew between this machine and Jira
+- Tempo and Slack authentication (when configured)
+- GitHub reachability for update checks
+- Local database integrity, schema version and unsynced entries
+
+Exits with a non-zero status when any check fails.` + configHelp,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	// State shared between checks, filled in as earlier checks succeed
+	var (
+		cfg         *config.Config
+		jiraClient  *jira.Client
+		currentUser *jira.IssueUser
+	)
+
+	checks := []doctor.Check{
+		{Name: "Config file", Run: func() doctor.Result {
+			configPath, err := config.GetConfigPath()
+			if err != nil {
+				return doctor.Fail(err.Error(), "Set TASKLOG_CONFIG to your config file path")
+			}
+			if _, err := os.Stat(configPath); os.IsNotExist(err) {
+				return doctor.Fail(fmt.Sprintf("Not found at %s", configPath), "Run 'tasklog init' to create one")
+			}
+			cfg, err = config.Load()
+			if err != nil {
+				return doctor.Fail(err.Error(), "Fix the field above, then run 'tasklog config compare' to spot other problems")
+			}
+			return doctor.Pass(configPath)
+		}},
+		{Name: "Config version", Run: func() doctor.Result {
+			if cfg == nil {
+				return doctor.Skip("Config not loaded")
+			}
+			if cfg.Version < config.CurrentConfigVersion {
+				return doctor.Warn(
+					fmt.Sprintf("Version %d is outdated (latest is %d)", cfg.Version, config.CurrentConfigVersion),
+					"Run 'tasklog config migrate'")
+			}
+			return doctor.Pass(fmt.Sprintf("Version %d", cfg.Version))
+		}},
+		{Name: "Jira authentication", Run: func() doctor.Result {
+			if cfg == nil {
+				return doctor.Skip("Config not loaded")
+			}
+			jiraClient = newJiraClient(cfg)
+			user, err := jiraClient.GetCurrentUser()
+			if err != nil {
+				if cfg.OAuth.Enabled() {
+					return doctor.Fail(err.Error(), "Run 'tasklog login' to sign in again")
+				}
+				if cfg.Jira.IsServer() {
+					return doctor.Fail(err.Error(),
+						"Check jira.url, jira.username and jira.api_token (a personal access token from your Jira profile)")
+				}
+				return doctor.Fail(err.Error(),
+					"Check jira.url, jira.username and jira.api_token (https://id.atlassian.com/manage-profile/security/api-tokens)")
+			}
+			currentUser = user
+			return doctor.Pass(fmt.Sprintf("Authenticated as %s at %s", user.DisplayName, cfg.Jira.URL))
+		}},
+		{Name: "Jira project", Run: func() doctor.Result {
+			if currentUser == nil {
+				return doctor.Skip("Jira not reachable")
+			}
+			project, err := jiraClient.GetProject(cfg.Jira.ProjectKey)
+			if err != nil {
+				return doctor.Fail(err.Error(), "Check jira.project_key and that your account can browse the project")
+			}
+			return doctor.Pass(fmt.Sprintf("%s - %s", project.Key, project.Name))
+		}},
+		{Name: "Jira time tracking", Run: func() doctor.Result {
+			if currentUser == nil {
+				return doctor.Skip("Jira not reachable")
+			}
+			enabled, err := jiraClient.IsTimeTrackingEnabled()
+			if err != nil {
+				return doctor.Warn(err.Error(), "Ask your Jira admin to confirm time tracking is enabled")
+			}
+			if !enabled {
+				return doctor.Fail("Time tracking is disabled", "Ask your Jira admin to enable it under Settings > Issues > Time tracking")
+			}
+			return doctor.Pass("Enabled")
+		}},
+		{Name: "Clock skew", Run: func() doctor.Result {
+			if currentUser == nil {
+				return doctor.Skip("Jira not reachable")
+			}
+			serverTime, err := jiraClient.GetServerTime()
+			if err != nil {
+				return doctor.Warn(err.Error(), "Clock skew could not be measured")
+			}
+			return doctor.CheckClockSkew(serverTime, time.Now())
+		}},
+		{Name: "Tempo authentication", Run: func() doctor.Result {
+			if cfg == nil || !cfg.Tempo.Enabled {
+				return doctor.Skip("Tempo not enabled")
+			}
+			if currentUser == nil {
+				return doctor.Skip("Jira account needed to query Tempo")
+			}
+			worklogs, err := newTempoClient(cfg).GetTodayWorklogs(currentUser.AccountID)
+			if err != nil {
+				return doctor.Fail(err.Error(), "Check tempo.api_token (Tempo > Settings > API Integration)")
+			}
+			return doctor.Pass(fmt.Sprintf("%d worklogs today", len(worklogs)))
+		}},
+		{Name: "Slack authentication", Run: func() doctor.Result {
+			if cfg == nil || cfg.Slack.UserToken == "" {
+				return doctor.Skip("Slack not configured")
+			}
+			identity, err := slack.NewClient(cfg.Slack.UserToken, cfg.Slack.ChannelID).AuthTest()
+			if err != nil {
+				return doctor.Fail(err.Error(), "Check slack.user_token is a user OAuth token (xoxp-...) with users.profile:write and chat:write scopes")
+			}
+			if cfg.Slack.ChannelID == "" {
+				return doctor.Warn(fmt.Sprintf("Authenticated as %s, but slack.channel_id is not set", identity.User),
+					"Set slack.channel_id to post break messages")
+			}
+			return doctor.Pass(fmt.Sprintf("Authenticated as %s in %s", identity.User, identity.Team))
+		}},
+		{Name: "GitHub (updates)", Run: func() doctor.Result {
+			if cfg != nil && cfg.Update.Disabled {
+				return doctor.Skip("Update checks disabled")
+			}
+			release, err := github.NewClient(githubOwner, githubRepo).GetLatestRelease()
+			if err != nil {
+				return doctor.Warn(err.Error(), "Check network access to api.github.com, or set update.disabled: true")
+			}
+			return doctor.Pass(fmt.Sprintf("Reachable (latest release %s)", release.TagName))
+		}},
+		{Name: "Database", Run: func() doctor.Result {
+			if cfg == nil {
+				return doctor.Skip("Config not loaded")
+			}
+			store, err := storage.NewStorage(cfg.Database.Path)
+			if err != nil {
+				return doctor.Fail(err.Error(), "Check database.path is writable")
+			}
+			defer store.Close()
+
+			if err := store.CheckIntegrity(); err != nil {
+				return doctor.Fail(err.Error(), fmt.Sprintf("Back up and remove %s to recreate it (local history will be lost)", cfg.Database.Path))
+			}
+
+			version, err := store.GetSchemaVersion()
+			if err != nil {
+				return doctor.Fail(err.Error(), "Check database.path points to a tasklog database")
+			}
+			if version > storage.SchemaVersion {
+				return doctor.Warn(fmt.Sprintf("Schema version %d is newer than this tasklog supports (%d)", version, storage.SchemaVersion),
+					"Upgrade tasklog to the version that created this database")
+			}
+			return doctor.Pass(fmt.Sprintf("%s (schema v%d, integrity ok)", cfg.Database.Path, version))
+		}},
+		{Name: "Unsynced entries", Run: func() doctor.Result {
+			if cfg == nil {
+				return doctor.Skip("Config not loaded")
+			}
+			store, err := storage.NewStorage(cfg.Database.Path)
+			if err != nil {
+				return doctor.Skip("Database not available")
+			}
+			defer store.Close()
+
+			count, err := store.CountUnsyncedEntries()
+			if err != nil {
+				return doctor.Fail(err.Error(), "Run 'tasklog doctor' again after fixing the database")
+			}
+			if count > 0 {
+				return doctor.Warn(fmt.Sprintf("%d entries not synced", count), "Run 'tasklog sync'")
+			}
+			return doctor.Pass("All entries synced")
+		}},
+	}
+
+	fmt.Println("🩺 Running tasklog health checks...")
+	fmt.Println()
+
+	results := doctor.Run(checks)
+	fmt.Print(doctor.FormatResults(results))
+
+	if doctor.HasFailures(results) {
+		return fmt.Errorf("some health checks failed")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+
+	"github.com/rs/zerolog/log"
+)
+
+// parseEstimate parses an --estimate value: auto, leave, new=2h or manual=30m
+func parseEstimate(cfg *config.Config, spec string) (jira.EstimateAdjustment, error) {
+	mode, value, hasValue := strings.Cut(strings.TrimSpace(spec), "=")
+	mode = strings.ToLower(strings.TrimSpace(mode))
+
+	switch mode {
+	case "", jira.EstimateAuto, jira.EstimateLeave:
+		if hasValue {
+			return jira.EstimateAdjustment{}, fmt.Errorf("--estimate %s doesn't take a duration", mode)
+		}
+		return jira.EstimateAdjustment{Mode: mode}, nil
+	case jira.EstimateNew, jira.EstimateManual:
+		if !hasValue {
+			return jira.EstimateAdjustment{}, fmt.Errorf("--estimate %s needs a duration, like %s=2h", mode, mode)
+		}
+		// The remaining estimate can be set to zero when no work is left; reducing it by nothing is pointless
+		parse := cfg.Time.TimeRules().Parse
+		if mode == jira.EstimateNew {
+			parse = cfg.Time.TimeRules().ParseEstimate
+		}
+		seconds, err := parse(value)
+		if err != nil {
+			return jira.EstimateAdjustment{}, fmt.Errorf("invalid --estimate duration: %w", err)
+		}
+		return jira.EstimateAdjustment{Mode: mode, Seconds: seconds}, nil
+	}
+	return jira.EstimateAdjustment{}, fmt.Errorf("invalid --estimate %q (expected auto, leave, new=2h or manual=30m)", spec)
+}
+
+// entryEstimate returns the estimate adjustment stored on a time entry, falling back to auto
+func entryEstimate(cfg *config.Config, entry *storage.TimeEntry) jira.EstimateAdjustment {
+	adjust, err := parseEstimate(cfg, entry.AdjustEstimate)
+	if err != nil {
+		log.Warn().Err(err).Int64("id", entry.ID).Msg("Ignoring invalid estimate adjustment")
+		return jira.EstimateAdjustment{}
+	}
+	return adjust
+}
+
+// describeEstimate renders an issue's estimates like "4h original, 1h 30m remaining, 2h 30m spent"
+func describeEstimate(tt *jira.TimeTracking) string {
+	if !tt.HasEstimate() {
+		spent := 0
+		if tt != nil {
+			spent = tt.TimeSpentSeconds
+		}
+		return fmt.Sprintf("none, %s spent", timeparse.Format(spent))
+	}
+	return fmt.Sprintf("%s original, %s remaining, %s spent", timeparse.Format(tt.OriginalEstimateSeconds),
+		timeparse.Format(tt.RemainingEstimateSeconds), timeparse.Format(tt.TimeSpentSeconds))
+}
+
+// describeAdjustment explains what logging does to the remaining estimate
+func describeAdjustment(adjust jira.EstimateAdjustment) string {
+	switch adjust.Mode {
+	case jira.EstimateLeave:
+		return "leave the remaining estimate unchanged"
+	case jira.EstimateNew:
+		return fmt.Sprintf("set the remaining estimate to %s", timeparse.Format(adjust.Seconds))
+	case jira.EstimateManual:
+		return fmt.Sprintf("reduce the remaining estimate by %s", timeparse.Format(adjust.Seconds))
+	}
+	return "reduce the remaining estimate by the time logged"
+}
+
+// estimateWarning returns a warning when logging seconds goes over the issue's estimate, or ""
+func estimateWarning(tt *jira.TimeTracking, seconds int) string {
+	if !tt.HasEstimate() {
+		return ""
+	}
+	if original := tt.OriginalEstimateSeconds; original > 0 && tt.TimeSpentSeconds+seconds > original {
+		return fmt.Sprintf("Logging %s brings the time spent to %s, over the %s original estimate",
+			timeparse.Format(seconds), timeparse.Format(tt.TimeSpentSeconds+seconds), timeparse.Format(original))
+	}
+	if seconds > tt.RemainingEstimateSeconds {
+		return fmt.Sprintf("Logging %s is more than the %s remaining estimate",
+			timeparse.Format(seconds), timeparse.Format(tt.RemainingEstimateSeconds))
+	}
+	return ""
+}
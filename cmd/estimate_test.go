@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/timeparse"
+)
+
+func TestParseEstimate(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		spec          string
+		expected      jira.EstimateAdjustment
+		expectedError bool
+	}{
+		{"", jira.EstimateAdjustment{}, false},
+		{"auto", jira.EstimateAdjustment{Mode: jira.EstimateAuto}, false},
+		{"Leave", jira.EstimateAdjustment{Mode: jira.EstimateLeave}, false},
+		{"new=2h 30m", jira.EstimateAdjustment{Mode: jira.EstimateNew, Seconds: 9000}, false},
+		{"manual=30m", jira.EstimateAdjustment{Mode: jira.EstimateManual, Seconds: 1800}, false},
+		{"new=0", jira.EstimateAdjustment{Mode: jira.EstimateNew, Seconds: 0}, false},
+		{"new=0m", jira.EstimateAdjustment{Mode: jira.EstimateNew, Seconds: 0}, false},
+		{"manual=0", jira.EstimateAdjustment{}, true},
+		{"new=-1h", jira.EstimateAdjustment{}, true},
+		{"new", jira.EstimateAdjustment{}, true},
+		{"leave=1h", jira.EstimateAdjustment{}, true},
+		{"manual=soon", jira.EstimateAdjustment{}, true},
+		{"shrink", jira.EstimateAdjustment{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			adjust, err := parseEstimate(cfg, tt.spec)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got %+v", adjust)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if adjust != tt.expected {
+				t.Errorf("got %+v, want %+v", adjust, tt.expected)
+			}
+
+			// The stored form parses back to the same adjustment
+			if !adjust.IsAuto() {
+				if again, err := parseEstimate(cfg, adjust.String()); err != nil || again != adjust {
+					t.Errorf("round trip of %q gave %+v, %v", adjust.String(), again, err)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateWarning(t *testing.T) {
+	hour := 3600
+	tests := []struct {
+		name     string
+		tracking *jira.TimeTracking
+		seconds  int
+		expected string
+	}{
+		{"no time tracking", nil, hour, ""},
+		{"no estimate", &jira.TimeTracking{TimeSpentSeconds: 5 * hour}, hour, ""},
+		{"within estimate", &jira.TimeTracking{OriginalEstimateSeconds: 4 * hour, RemainingEstimateSeconds: 2 * hour, TimeSpentSeconds: 2 * hour}, hour, ""},
+		{"over original", &jira.TimeTracking{OriginalEstimateSeconds: 4 * hour, RemainingEstimateSeconds: hour, TimeSpentSeconds: 3 * hour}, 2 * hour, "brings the time spent to 5h, over the 4h original estimate"},
+		{"over remaining", &jira.TimeTracking{RemainingEstimateSeconds: hour}, 2 * hour, "more than the 1h remaining estimate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := estimateWarning(tt.tracking, tt.seconds)
+			if tt.expected == "" && warning != "" {
+				t.Errorf("expected no warning, got %q", warning)
+			}
+			if !strings.Contains(warning, tt.expected) {
+				t.Errorf("warning %q doesn't contain %q", warning, tt.expected)
+			}
+		})
+	}
+
+	if got := describeEstimate(&jira.TimeTracking{OriginalEstimateSeconds: 4 * hour, RemainingEstimateSeconds: 5400, TimeSpentSeconds: 9000}); got != "4h original, 1h 30m remaining, 2h 30m spent" {
+		t.Errorf("describeEstimate() = %q", got)
+	}
+	if got := describeEstimate(nil); got != "none, "+timeparse.Format(0)+" spent" {
+		t.Errorf("describeEstimate(nil) = %q", got)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/scheduler"
+	"tasklog/internal/slack"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+var focusLabel string
+
+var focusCmd = &cobra.Command{
+	Use:   "focus <duration> [task-key]",
+	Short: "Start a focus session that pauses Slack and logs the time",
+	Long: `Starts a focus session for a Jira task:
+- Runs a timer for the task, like 'tasklog start'
+- Pauses Slack notifications and sets your presence to away
+- Rings and shows a desktop notification when the session is over
+- Resumes notifications, sets presence back to auto and logs the time
+
+Press Ctrl+C to end the session early; the time so far is still logged.
+If tasklog is killed, the timer keeps running and can be logged with 'tasklog stop'.
+
+Examples:
+  tasklog focus 90m              # Select from in-progress tasks
+  tasklog focus 1h PROJ-123      # Focus on a specific task
+  tasklog focus 45m -l development` + configHelp,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runFocus,
+}
+
+func init() {
+	rootCmd.AddCommand(focusCmd)
+
+	focusCmd.Flags().StringVarP(&focusLabel, "label", "l", "", "Work log label")
+}
+
+func runFocus(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	focusSeconds, err := timeparse.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	duration := time.Duration(focusSeconds) * time.Second
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	active, err := store.GetActiveTimer()
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		return fmt.Errorf("a timer is already running for %s since %s; run 'tasklog stop' first",
+			active.IssueKey, active.StartedAt.Format("15:04"))
+	}
+
+	key := ""
+	if len(args) > 1 {
+		key = args[1]
+	}
+
+	jiraClient := newJiraClient(cfg)
+	issue, err := selectIssue(jiraClient, cfg, key)
+	if err != nil {
+		return err
+	}
+
+	timer, err := startTimer(cfg, store, issue)
+	if err != nil {
+		return err
+	}
+	end := timer.StartedAt.Add(duration)
+
+	pauseSlack(cfg, end, true, true)
+
+	fmt.Printf("🎯 Focusing on %s - %s until %s\n", issue.Key, issue.Fields.Summary, end.Format("15:04"))
+	fmt.Println("Press Ctrl+C to end the session early.")
+
+	// Wait for the session to end or for Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if waitUntil(ctx, end) {
+		scheduler.Notify("Focus session over", fmt.Sprintf("%s - %s", issue.Key, issue.Fields.Summary))
+		fmt.Println("\n⏰ Focus session over")
+	} else {
+		fmt.Println("\n⏹️  Focus session ended early")
+	}
+	stop()
+
+	resumeSlack(cfg, true, true)
+
+	now := time.Now()
+	timeSeconds := elapsedSeconds(timer.StartedAt, now)
+	fmt.Printf("⏱️  %s - %s: %s (since %s)\n", timer.IssueKey, timer.IssueSummary,
+		timeparse.Format(timeSeconds), timer.StartedAt.Format("15:04"))
+
+	return logTimerWithIdle(cfg, store, timer, focusLabel, now)
+}
+
+// pauseSlack snoozes Slack notifications and/or sets presence to away until the given time
+// Does nothing without a Slack user token; failures are only logged
+func pauseSlack(cfg *config.Config, until time.Time, snooze, away bool) {
+	if cfg.Slack.UserToken == "" || !snooze && !away {
+		return
+	}
+	slackClient := slack.NewClient(cfg.Slack.UserToken, cfg.Slack.ChannelID)
+
+	if snooze {
+		// Round up so notifications stay paused until the end
+		minutes := int(math.Ceil(time.Until(until).Minutes()))
+		if minutes < 1 {
+			minutes = 1
+		}
+		if err := slackClient.SetSnooze(minutes); err != nil {
+			log.Error().Err(err).Msg("Failed to snooze Slack notifications")
+		} else {
+			fmt.Printf("🔕 Slack notifications paused until %s\n", until.Format("15:04"))
+		}
+	}
+
+	if away {
+		if err := slackClient.SetPresence(slack.PresenceAway); err != nil {
+			log.Error().Err(err).Msg("Failed to set Slack presence")
+		} else {
+			fmt.Println("🌙 Slack presence set to away")
+		}
+	}
+}
+
+// resumeSlack undoes pauseSlack: resumes notifications and/or sets presence back to auto
+func resumeSlack(cfg *config.Config, snooze, away bool) {
+	if cfg.Slack.UserToken == "" || !snooze && !away {
+		return
+	}
+	slackClient := slack.NewClient(cfg.Slack.UserToken, cfg.Slack.ChannelID)
+
+	if snooze {
+		if err := slackClient.EndSnooze(); err != nil {
+			log.Error().Err(err).Msg("Failed to resume Slack notifications")
+		} else {
+			fmt.Println("🔔 Slack notifications resumed")
+		}
+	}
+
+	if away {
+		if err := slackClient.SetPresence(slack.PresenceAuto); err != nil {
+			log.Error().Err(err).Msg("Failed to reset Slack presence")
+		} else {
+			fmt.Println("🟢 Slack presence set back to auto")
+		}
+	}
+}
@@ -3,16 +3,29 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/slack"
+	"tasklog/internal/tempo"
+	"tasklog/internal/ui"
 
 	"github.com/spf13/cobra"
 )
 
+var initExample bool
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize tasklog configuration",
-	Long: `Creates the configuration directory and an example config file at ~/.tasklog/config.yaml
+	Long: `Creates the configuration directory and a config file at ~/.tasklog/config.yaml
+
+When run in a terminal, an interactive wizard asks for your Jira URL, email and
+API token, verifies them live, and lets you pick your project, task statuses and
+labels. Tempo and Slack tokens are tested before they are saved, so the resulting
+config is valid the first time. Use --example to write the example template instead.
 
 If a config file already exists, use 'tasklog config example' to view the template
 and update your config manually.
@@ -26,6 +39,8 @@ Helpful commands:
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().BoolVar(&initExample, "example", false, "Write the example config template instead of running the interactive wizard")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -51,7 +66,11 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	return createNewConfig(configPath)
+	if initExample || !ui.IsInteractive() {
+		return createNewConfig(configPath)
+	}
+
+	return runInitWizard(configPath)
 }
 
 // createNewConfig generates and writes a new config file
@@ -89,3 +108,307 @@ func printError(message string, err error) error {
 	fmt.Printf("%s: %v\n", message, err)
 	return nil
 }
+
+// runInitWizard interactively builds and verifies a config before writing it
+func runInitWizard(configPath string) error {
+	fmt.Println("👋 Welcome to tasklog! Let's set up your configuration.")
+	fmt.Println("   Credentials are verified as you go. Press Ctrl+C to cancel at any time.")
+	fmt.Println()
+
+	cfg := &config.Config{
+		Version: config.CurrentConfigVersion,
+		Update: config.UpdateConfig{
+			CheckInterval: "24h",
+		},
+	}
+
+	jiraClient, currentUser, err := setupJira(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := setupProject(cfg, jiraClient); err != nil {
+		return err
+	}
+
+	if err := setupTempo(cfg, currentUser); err != nil {
+		return err
+	}
+
+	if err := setupSlack(cfg); err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("generated configuration is invalid: %w", err)
+	}
+
+	data, err := config.GenerateConfig(cfg)
+	if err != nil {
+		return printError("failed to generate config", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return printError("failed to create config file", err)
+	}
+
+	fmt.Println()
+	fmt.Println("✓ Configuration initialized successfully!")
+	fmt.Printf("\nConfig file created at: %s\n", configPath)
+	fmt.Println("\nNext steps:")
+	fmt.Println("1. (Optional) Add shortcuts: tasklog config shortcut add daily --task PROJ-123 --time 30m --label meeting")
+	fmt.Println("2. Run: tasklog log")
+	return nil
+}
+
+// setupJira prompts for Jira credentials until they can be verified
+func setupJira(cfg *config.Config) (*jira.Client, *jira.IssueUser, error) {
+	fmt.Println("── Jira ──")
+
+	for {
+		url, err := ui.PromptInput("Jira URL (e.g., https://your-domain.atlassian.net):", cfg.Jira.URL, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		deployment, err := selectJiraDeployment(url)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		usernamePrompt := "Jira email:"
+		tokenPrompt, tokenHelp := "Jira API token:", "Create one at https://id.atlassian.com/manage-profile/security/api-tokens"
+		if deployment == config.JiraServer {
+			usernamePrompt = "Jira username:"
+			tokenPrompt, tokenHelp = "Jira personal access token:", "Create one in Jira under Profile > Personal Access Tokens"
+		}
+
+		username, err := ui.PromptInput(usernamePrompt, cfg.Jira.Username, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		token, err := ui.PromptSecret(tokenPrompt,
+			tokenHelp+"\nYou can also enter a secret reference such as env:JIRA_TOKEN")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cfg.Jira.URL = strings.TrimSuffix(url, "/")
+		cfg.Jira.Deployment = deployment
+		cfg.Jira.Username = username
+		cfg.Jira.APIToken = token
+
+		resolvedToken, err := config.ResolveSecret(token)
+		if err == nil {
+			fmt.Println("🔍 Verifying Jira credentials...")
+			client := jira.NewClient(cfg.Jira.URL, username, resolvedToken, "")
+			if deployment == config.JiraServer {
+				client = jira.NewServerClient(cfg.Jira.URL, username, resolvedToken, "")
+			}
+
+			var user *jira.IssueUser
+			user, err = client.GetCurrentUser()
+			if err == nil {
+				fmt.Printf("✓ Authenticated as %s\n\n", user.DisplayName)
+				return client, user, nil
+			}
+		}
+
+		fmt.Printf("❌ Could not verify Jira credentials: %v\n", err)
+		retry, err := ui.Confirm("Try again?")
+		if err != nil {
+			return nil, nil, err
+		}
+		if !retry {
+			return nil, nil, fmt.Errorf("jira credentials could not be verified")
+		}
+	}
+}
+
+// setupProject lets the user pick the project, task statuses and allowed labels
+func setupProject(cfg *config.Config, client *jira.Client) error {
+	projects, err := client.GetProjects()
+	if err != nil || len(projects) == 0 {
+		if err != nil {
+			fmt.Printf("⚠️  Could not fetch projects: %v\n", err)
+		}
+		cfg.Jira.ProjectKey, err = ui.PromptInput("Jira project key (e.g., PROJ):", "", true)
+		if err != nil {
+			return err
+		}
+	} else {
+		options := make([]string, len(projects))
+		for i, project := range projects {
+			options[i] = fmt.Sprintf("%s - %s", project.Key, project.Name)
+		}
+		selected, err := ui.Select("Select your Jira project:", options)
+		if err != nil {
+			return err
+		}
+		cfg.Jira.ProjectKey = projects[slices.Index(options, selected)].Key
+	}
+
+	statuses, err := client.GetProjectStatuses(cfg.Jira.ProjectKey)
+	if err != nil || len(statuses) == 0 {
+		fmt.Println("⚠️  Could not fetch project statuses, using the default (In Progress)")
+	} else {
+		cfg.Jira.TaskStatuses, err = ui.MultiSelect(
+			"Which statuses should be listed when picking a task?",
+			statuses,
+			filterDefaults(statuses, []string{"In Progress", "In Review"}),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	labels, err := client.GetLabels()
+	if err != nil {
+		fmt.Printf("⚠️  Could not fetch labels: %v\n", err)
+	} else if len(labels) > 0 {
+		cfg.Labels.AllowedLabels, err = ui.MultiSelect("Which labels can be used for time logging? (none selected = any label)", labels, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// selectJiraDeployment treats Atlassian-hosted URLs as Jira Cloud and asks about any other URL
+func selectJiraDeployment(url string) (string, error) {
+	if isJiraCloudURL(url) {
+		return config.JiraCloud, nil
+	}
+
+	const server = "Jira Server / Data Center (self-hosted)"
+	selected, err := ui.Select("Which Jira do you use?", []string{server, "Jira Cloud"})
+	if err != nil {
+		return "", err
+	}
+	if selected == server {
+		return config.JiraServer, nil
+	}
+	return config.JiraCloud, nil
+}
+
+// isJiraCloudURL reports whether url points to an Atlassian Cloud site
+func isJiraCloudURL(url string) bool {
+	host := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	return strings.HasSuffix(strings.ToLower(host), ".atlassian.net")
+}
+
+// setupTempo optionally configures and verifies a Tempo API token
+func setupTempo(cfg *config.Config, user *jira.IssueUser) error {
+	fmt.Println("── Tempo ──")
+
+	if cfg.Jira.IsServer() {
+		fmt.Println("Tempo is only supported with Jira Cloud, skipping")
+		fmt.Println()
+		return nil
+	}
+
+	enabled, err := ui.Confirm("Do you use Tempo? (needed for 'tasklog summary')")
+	if err != nil || !enabled {
+		fmt.Println()
+		return err
+	}
+
+	for {
+		token, err := ui.PromptSecret("Tempo API token:", "Get it from Tempo > Settings > API Integration\nYou can also enter a secret reference such as env:TEMPO_TOKEN")
+		if err != nil {
+			return err
+		}
+
+		resolvedToken, err := config.ResolveSecret(token)
+		if err == nil {
+			fmt.Println("🔍 Verifying Tempo token...")
+			_, err = tempo.NewClient(resolvedToken).GetTodayWorklogs(user.AccountID)
+			if err == nil {
+				fmt.Printf("✓ Tempo token works\n\n")
+				cfg.Tempo.Enabled = true
+				cfg.Tempo.APIToken = token
+				return nil
+			}
+		}
+
+		fmt.Printf("❌ Could not verify Tempo token: %v\n", err)
+		retry, err := ui.Confirm("Try again? (No skips Tempo)")
+		if err != nil {
+			return err
+		}
+		if !retry {
+			fmt.Println()
+			return nil
+		}
+	}
+}
+
+// setupSlack optionally configures and verifies Slack for break notifications
+func setupSlack(cfg *config.Config) error {
+	fmt.Println("── Slack ──")
+
+	enabled, err := ui.Confirm("Set up Slack for break notifications?")
+	if err != nil || !enabled {
+		return err
+	}
+
+	for {
+		token, err := ui.PromptSecret("Slack user OAuth token (xoxp-...):", "See the Slack Setup section of the README\nYou can also enter a secret reference such as env:SLACK_TOKEN")
+		if err != nil {
+			return err
+		}
+
+		resolvedToken, err := config.ResolveSecret(token)
+		if err == nil {
+			fmt.Println("🔍 Verifying Slack token...")
+			var identity *slack.AuthIdentity
+			identity, err = slack.NewClient(resolvedToken, "").AuthTest()
+			if err == nil {
+				fmt.Printf("✓ Authenticated as %s in %s\n", identity.User, identity.Team)
+				cfg.Slack.UserToken = token
+				break
+			}
+		}
+
+		fmt.Printf("❌ Could not verify Slack token: %v\n", err)
+		retry, err := ui.Confirm("Try again? (No skips Slack)")
+		if err != nil {
+			return err
+		}
+		if !retry {
+			return nil
+		}
+	}
+
+	cfg.Slack.ChannelID, err = ui.PromptInput("Slack channel ID for break messages (e.g., C1234567890):", "", true)
+	if err != nil {
+		return err
+	}
+
+	addBreaks, err := ui.Confirm("Add default breaks (lunch, prayer, coffee)?")
+	if err != nil {
+		return err
+	}
+	if addBreaks {
+		cfg.Slack.Breaks = []config.BreakEntry{
+			{Name: "lunch", Duration: 60, Emoji: ":fork_and_knife:"},
+			{Name: "prayer", Duration: 15, Emoji: ":pray:"},
+			{Name: "coffee", Duration: 10, Emoji: ":coffee:"},
+		}
+	}
+
+	return nil
+}
+
+// filterDefaults returns the wanted values that are present in options
+func filterDefaults(options, wanted []string) []string {
+	var defaults []string
+	for _, value := range wanted {
+		if slices.Contains(options, value) {
+			defaults = append(defaults, value)
+		}
+	}
+	return defaults
+}
@@ -93,3 +93,37 @@ func TestGenerateExampleConfig(t *testing.T) {
 		t.Error("expected example config to contain helpful comments")
 	}
 }
+
+// TestFilterDefaults tests picking wizard defaults from the fetched options
+func TestFilterDefaults(t *testing.T) {
+	options := []string{"To Do", "In Progress", "Done"}
+
+	defaults := filterDefaults(options, []string{"In Progress", "In Review"})
+	if len(defaults) != 1 || defaults[0] != "In Progress" {
+		t.Errorf("expected [In Progress], got %v", defaults)
+	}
+
+	if defaults := filterDefaults(options, []string{"Blocked"}); len(defaults) != 0 {
+		t.Errorf("expected no defaults, got %v", defaults)
+	}
+}
+
+// TestIsJiraCloudURL tests telling Atlassian Cloud sites from self-hosted Jira
+func TestIsJiraCloudURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://your-domain.atlassian.net", true},
+		{"https://Your-Domain.Atlassian.net/", true},
+		{"https://jira.example.com", false},
+		{"https://jira.example.com/atlassian.net", false},
+		{"http://localhost:8080", false},
+	}
+
+	for _, tt := range tests {
+		if got := isJiraCloudURL(tt.url); got != tt.expected {
+			t.Errorf("isJiraCloudURL(%q) = %v, want %v", tt.url, got, tt.expected)
+		}
+	}
+}
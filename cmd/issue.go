@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/ui"
+)
+
+// defaultIssueType is offered first when creating an issue, if the project has it
+const defaultIssueType = "Task"
+
+var (
+	issueProject string
+	issueType    string
+	issueSummary string
+	issueParent  string
+
+	// newIssue is the issue 'tasklog issue create' hands to 'tasklog log', created once the entry is confirmed
+	newIssue *jira.IssueDraft
+)
+
+var issueCmd = &cobra.Command{
+	Use:   "issue",
+	Short: "Manage Jira issues",
+}
+
+var issueCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a Jira issue and log time to it",
+	Long: `Creates a Jira issue for unplanned work that has no ticket yet,
+then logs time to it right away, like 'tasklog log --task <new key>'.
+
+Missing values are asked for; the project defaults to jira.project_key.
+The issue is only created once the time entry is confirmed.
+
+Examples:
+  tasklog issue create                                     # Interactive mode
+  tasklog issue create -s "Helped ops with outage" -d 1h   # Default project and type picker
+  tasklog issue create -s "Hotfix release" --type Bug --parent PROJ-100 -d 30m -l bug-fix` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runIssueCreate,
+}
+
+func init() {
+	rootCmd.AddCommand(issueCmd)
+	issueCmd.AddCommand(issueCreateCmd)
+
+	issueCreateCmd.Flags().StringVarP(&issueProject, "project", "p", "", "Project key (default: jira.project_key)")
+	issueCreateCmd.Flags().StringVar(&issueType, "type", "", "Issue type (e.g., Task, Bug)")
+	issueCreateCmd.Flags().StringVarP(&issueSummary, "summary", "s", "", "Issue summary")
+	issueCreateCmd.Flags().StringVar(&issueParent, "parent", "", "Parent epic key (e.g., PROJ-100)")
+
+	// The new issue is logged to with the same flags as 'tasklog log'
+	issueCreateCmd.Flags().StringVarP(&timeSpent, "time", "d", "", "Time spent (e.g., 2h 30m, 2.5h, 0.5d, 09:15-11:40, since 14:00)")
+	issueCreateCmd.Flags().StringVarP(&label, "label", "l", "", "Work log label")
+	issueCreateCmd.Flags().BoolVarP(&useEditor, "editor", "e", false, "Write the comment in $EDITOR (multi-line markdown)")
+	issueCreateCmd.Flags().StringVar(&estimateSpec, "estimate", "", "Adjust the remaining estimate: auto, leave, new=2h or manual=30m (default: auto)")
+	issueCreateCmd.Flags().StringVar(&transitionTo, "transition", "", "Status to move the new issue to after logging (e.g., Done); \"none\" skips the question")
+}
+
+func runIssueCreate(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	draft, err := completeDraft(newJiraClient(cfg), cfg, jira.IssueDraft{
+		ProjectKey: issueProject,
+		IssueType:  issueType,
+		Summary:    issueSummary,
+		ParentKey:  issueParent,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	newIssue = draft
+	return runLog(cmd, nil)
+}
+
+// completeDraft asks for the missing parts of a new issue; it is created later by createIssue.
+// The parent epic is only asked for when the summary is asked for too
+func completeDraft(jiraClient *jira.Client, cfg *config.Config, draft jira.IssueDraft) (*jira.IssueDraft, error) {
+	if draft.ProjectKey == "" {
+		draft.ProjectKey = cfg.Jira.ProjectKey
+	}
+	draft.ProjectKey = strings.ToUpper(draft.ProjectKey)
+
+	if draft.Summary == "" {
+		summary, err := ui.PromptInput("Summary of the new task:", "", true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get summary: %w", err)
+		}
+		draft.Summary = summary
+
+		if draft.ParentKey == "" {
+			parent, err := ui.PromptInput("Parent epic key (optional):", "", false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent epic: %w", err)
+			}
+			draft.ParentKey = parent
+		}
+	}
+	draft.ParentKey = strings.ToUpper(draft.ParentKey)
+
+	if draft.IssueType == "" {
+		issueTypes, err := jiraClient.GetIssueTypes(draft.ProjectKey)
+		if err != nil {
+			return nil, err
+		}
+		switch len(issueTypes) {
+		case 0:
+			draft.IssueType = defaultIssueType
+		case 1:
+			draft.IssueType = issueTypes[0]
+		default:
+			draft.IssueType, err = ui.Select("Issue type:", defaultIssueTypeFirst(issueTypes))
+			if err != nil {
+				return nil, fmt.Errorf("failed to select issue type: %w", err)
+			}
+		}
+	}
+
+	return &draft, nil
+}
+
+// createIssue creates the issue described by draft
+func createIssue(jiraClient *jira.Client, draft *jira.IssueDraft) (*jira.Issue, error) {
+	issue, err := jiraClient.CreateIssue(*draft)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("✓ Created %s - %s\n", issue.Key, issue.Fields.Summary)
+	return issue, nil
+}
+
+// draftIssue stands in for an issue that isn't created yet. Its key only names it in messages
+func draftIssue(draft *jira.IssueDraft) *jira.Issue {
+	return &jira.Issue{
+		Key: draftKey(draft),
+		Fields: jira.IssueFields{
+			Summary:   draft.Summary,
+			IssueType: jira.IssueType{Name: draft.IssueType},
+		},
+	}
+}
+
+// draftKey names a new issue before it has a key (e.g., "new PROJ Task")
+func draftKey(draft *jira.IssueDraft) string {
+	return fmt.Sprintf("new %s %s", draft.ProjectKey, draft.IssueType)
+}
+
+// defaultIssueTypeFirst moves the default issue type to the top so it is preselected
+func defaultIssueTypeFirst(issueTypes []string) []string {
+	ordered := make([]string, 0, len(issueTypes))
+	for _, name := range issueTypes {
+		if strings.EqualFold(name, defaultIssueType) {
+			ordered = append([]string{name}, ordered...)
+		} else {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
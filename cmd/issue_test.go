@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"tasklog/internal/jira"
+)
+
+func TestDefaultIssueTypeFirst(t *testing.T) {
+	tests := []struct {
+		issueTypes []string
+		expected   string
+	}{
+		{[]string{"Bug", "Story", "Task"}, "Task,Bug,Story"},
+		{[]string{"Bug", "task"}, "task,Bug"},
+		{[]string{"Bug", "Story"}, "Bug,Story"},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(defaultIssueTypeFirst(tt.issueTypes), ","); got != tt.expected {
+			t.Errorf("defaultIssueTypeFirst(%v) = %q, want %q", tt.issueTypes, got, tt.expected)
+		}
+	}
+}
+
+func TestDraftIssue(t *testing.T) {
+	draft := &jira.IssueDraft{ProjectKey: "PROJ", IssueType: "Bug", Summary: "Hotfix release"}
+
+	issue := draftIssue(draft)
+	if issue.Key != "new PROJ Bug" {
+		t.Errorf("expected the draft to be named in messages, got %q", issue.Key)
+	}
+	if issue.Fields.Summary != "Hotfix release" || issue.Fields.IssueType.Name != "Bug" {
+		t.Errorf("unexpected draft issue: %+v", issue.Fields)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -9,6 +10,7 @@ import (
 
 	"tasklog/internal/config"
 	"tasklog/internal/jira"
+	"tasklog/internal/policy"
 	"tasklog/internal/storage"
 	"tasklog/internal/tempo"
 	"tasklog/internal/timeparse"
@@ -20,6 +22,10 @@ var (
 	taskKey      string
 	timeSpent    string
 	label        string
+	splitSpec    string
+	useEditor    bool
+	estimateSpec string
+	transitionTo string
 )
 
 var logCmd = &cobra.Command{
@@ -32,7 +38,11 @@ Examples:
   tasklog log              # Interactive mode
   tasklog log daily        # Use 'daily' shortcut
   tasklog log standup      # Use 'standup' shortcut
-  tasklog log -t PROJ-123  # Log to specific task` + configHelp,
+  tasklog log -t PROJ-123  # Log to specific task
+
+Split one duration across several tasks (consecutive worklogs, one confirmation):
+  tasklog log --split "PROJ-1=1h,PROJ-2=45m,PROJ-3=rest" --time 3h
+  tasklog log --split      # Pick in-progress tasks and enter the time for each` + configHelp,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runLog,
 }
@@ -41,8 +51,13 @@ func init() {
 	rootCmd.AddCommand(logCmd)
 
 	logCmd.Flags().StringVarP(&taskKey, "task", "t", "", "Task key (e.g., PROJ-123)")
-	logCmd.Flags().StringVarP(&timeSpent, "time", "d", "", "Time spent (e.g., 2h 30m, 2.5h, 150m)")
+	logCmd.Flags().StringVarP(&timeSpent, "time", "d", "", "Time spent (e.g., 2h 30m, 2.5h, 0.5d, 09:15-11:40, since 14:00)")
 	logCmd.Flags().StringVarP(&label, "label", "l", "", "Work log label")
+	logCmd.Flags().BoolVarP(&useEditor, "editor", "e", false, "Write the comment in $EDITOR (multi-line markdown)")
+	logCmd.Flags().StringVar(&estimateSpec, "estimate", "", "Adjust the remaining estimate: auto, leave, new=2h or manual=30m (default: auto)")
+	logCmd.Flags().StringVar(&transitionTo, "transition", "", "Transition to make after logging, by name or target status (e.g., Done); \"none\" skips the question")
+	logCmd.Flags().StringVar(&splitSpec, "split", "", "Split the time across tasks (e.g., PROJ-1=1h,PROJ-2=rest); without a value, pick tasks interactively")
+	logCmd.Flags().Lookup("split").NoOptDefVal = splitPrompt
 
 	// Set custom usage template to show available shortcuts
 	logCmd.SetUsageFunc(logUsageFunc)
@@ -61,6 +76,9 @@ func logUsageFunc(cmd *cobra.Command) error {
 			if sc.Time != "" {
 				timeInfo = fmt.Sprintf(" (%s)", sc.Time)
 			}
+			if sc.Transition != "" {
+				timeInfo += fmt.Sprintf(" → %s", sc.Transition)
+			}
 			fmt.Fprintf(cmd.OutOrStderr(), "  %-15s %s - %s%s\n", sc.Name, sc.Task, sc.Label, timeInfo)
 		}
 		fmt.Fprintf(cmd.OutOrStderr(), "\n")
@@ -85,8 +103,8 @@ func runLog(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize clients
-	jiraClient := jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
-	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
+	jiraClient := newJiraClient(cfg)
+	tempoClient := newTempoClient(cfg)
 
 	// Initialize storage
 	store, err := storage.NewStorage(cfg.Database.Path)
@@ -95,6 +113,15 @@ func runLog(cmd *cobra.Command, args []string) error {
 	}
 	defer store.Close()
 
+	adjust, err := parseEstimate(cfg, estimateSpec)
+	if err != nil {
+		return err
+	}
+
+	if splitSpec != "" {
+		return runSplitLog(cfg, store, jiraClient, tempoClient, splitSpec, adjust)
+	}
+
 	var selectedIssue *jira.Issue
 	var timeSeconds int
 	var selectedLabel string
@@ -118,126 +145,403 @@ func runLog(cmd *cobra.Command, args []string) error {
 		if label == "" {
 			label = shortcut.Label
 		}
+		if transitionTo == "" {
+			transitionTo = shortcut.Transition
+		}
 	}
 
-	// Get task
-	if taskKey != "" {
-		log.Debug().Str("task", taskKey).Msg("Fetching specified task")
-		issue, err := jiraClient.GetIssue(taskKey)
+	// Get task; a new task is only created once the time entry is confirmed
+	draft := newIssue
+	if draft == nil {
+		selectedIssue, draft, err = selectIssueOrDraft(jiraClient, cfg, taskKey)
 		if err != nil {
-			return fmt.Errorf("failed to fetch task %s: %w", taskKey, err)
+			return err
 		}
-		selectedIssue = issue
-		fmt.Printf("Task: %s - %s\n", selectedIssue.Key, selectedIssue.Fields.Summary)
+	}
+	if draft != nil {
+		selectedIssue = draftIssue(draft)
+	}
+
+	// Get time spent; ranges and "since" also set the start time
+	timeEntry, err := promptTimeEntry(cfg, timeSpent, time.Now())
+	if err != nil {
+		return err
+	}
+	timeSeconds = timeEntry.Seconds
+
+	// Get label
+	selectedLabel, err = selectWorkLabel(cfg, label)
+	if err != nil {
+		return err
+	}
+
+	// Get optional comment
+	comment, err := promptComment()
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	// Offer to move the issue along, e.g. to Review after the last chunk of work. A new task has no
+	// transitions until it is created, so it offers the statuses of its workflow instead
+	var transition *jira.Transition
+	if draft == nil {
+		transition, err = selectTransition(jiraClient, selectedIssue, transitionTo)
 	} else {
-		// Interactive task selection
-		log.Debug().Msg("Fetching in-progress tasks")
-		inProgressIssues, err := jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
-		if err != nil {
-			return fmt.Errorf("failed to fetch in-progress tasks: %w", err)
-		}
+		transition, err = selectDraftTransition(jiraClient, draft, transitionTo)
+	}
+	if err != nil {
+		return err
+	}
 
-		selectedIssue, err = ui.SelectTask(inProgressIssues)
-		if err != nil {
-			return fmt.Errorf("failed to select task: %w", err)
+	project := policy.ProjectKey(selectedIssue.Key)
+	if draft != nil {
+		project = draft.ProjectKey
+	}
+	if err := enforcePolicy(cfg, policy.Worklog{
+		IssueKey:  selectedIssue.Key,
+		Project:   project,
+		IssueType: selectedIssue.Fields.IssueType.Name,
+		Label:     selectedLabel,
+		Comment:   comment,
+		Seconds:   timeSeconds,
+	}); err != nil {
+		return err
+	}
+
+	// Create time entry; plain durations start when they are logged, ranges and "since" set the real start
+	now := time.Now()
+	started := timeEntry.Started
+	if started.IsZero() {
+		started = now
+	}
+	entry := &storage.TimeEntry{
+		IssueKey:         selectedIssue.Key,
+		IssueSummary:     selectedIssue.Fields.Summary,
+		TimeSpentSeconds: timeSeconds,
+		TimeSpent:        timeparse.Format(timeSeconds),
+		Label:            selectedLabel,
+		Comment:          comment,
+		Started:          started,
+		SyncedToJira:     false,
+		SyncedToTempo:    false,
+		StartExact:       !timeEntry.Started.IsZero(),
+	}
+	if !adjust.IsAuto() {
+		entry.AdjustEstimate = adjust.String()
+	}
+
+	// Confirm before logging
+	fmt.Printf("\n")
+	fmt.Printf("Task:    %s - %s\n", selectedIssue.Key, selectedIssue.Fields.Summary)
+	fmt.Printf("Time:    %s\n", timeparse.Format(timeSeconds))
+	if !timeEntry.Started.IsZero() {
+		fmt.Printf("Started: %s\n", timeEntry.Started.Format("15:04"))
+	}
+	timeTracking := selectedIssue.Fields.TimeTracking
+	if timeTracking != nil {
+		fmt.Printf("Estimate: %s\n", describeEstimate(timeTracking))
+	}
+	if !adjust.IsAuto() {
+		fmt.Printf("Adjust:  %s\n", describeAdjustment(adjust))
+	}
+	if transition != nil {
+		fmt.Printf("Move to: %s\n", transition.Label())
+	}
+	fmt.Printf("Label:   %s\n", selectedLabel)
+	if comment != "" {
+		fmt.Printf("Comment: %s\n", indentComment(comment))
+	}
+	fmt.Printf("\n")
+
+	if err := checkWorklogs(cfg, store, entryWorklogs([]*storage.TimeEntry{entry}), now); err != nil {
+		return err
+	}
+	if warning := estimateWarning(timeTracking, timeSeconds); warning != "" {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	confirmed, err := ui.Confirm("Log this time entry?")
+	if err != nil {
+		return fmt.Errorf("failed to confirm: %w", err)
+	}
+
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	if draft != nil {
+		if selectedIssue, err = createIssue(jiraClient, draft); err != nil {
+			return err
 		}
+		entry.IssueKey = selectedIssue.Key
+	}
 
-		// If user chose to search, perform the search
-		if selectedIssue.Fields.Summary == "" {
-			searchResults, err := jiraClient.SearchIssues(selectedIssue.Key)
-			if err != nil {
-				return fmt.Errorf("failed to search tasks: %w", err)
-			}
+	if err := saveTimeEntry(store, jiraClient, cfg, entry); err != nil {
+		return err
+	}
 
-			selectedIssue, err = ui.SelectFromSearchResults(searchResults)
-			if err != nil {
-				return fmt.Errorf("failed to select from search results: %w", err)
+	// Only move the issue along once the work is actually in Jira
+	if transition != nil {
+		if !entry.SyncedToJira {
+			fmt.Printf("ℹ️  Skipped moving %s to %s because the time wasn't logged to Jira\n", selectedIssue.Key, transitionTarget(transition))
+		} else if transition, err = resolveTransition(jiraClient, selectedIssue, transition); err != nil {
+			log.Error().Err(err).Msg("Failed to find the transition")
+			fmt.Printf("⚠ Failed to move %s: %v\n", selectedIssue.Key, err)
+		} else if err := jiraClient.TransitionIssue(selectedIssue.Key, transition.ID); err != nil {
+			log.Error().Err(err).Msg("Failed to transition issue")
+			fmt.Printf("⚠ Failed to move %s: %v\n", selectedIssue.Key, err)
+		} else {
+			fmt.Printf("✓ Moved %s to %s\n", selectedIssue.Key, transitionTarget(transition))
+			entry.Transition = transition.Name
+			if err := store.UpdateTimeEntry(entry); err != nil {
+				log.Error().Err(err).Msg("Failed to record the transition")
 			}
+		}
+	}
 
-			// Fetch full issue details
-			issue, err := jiraClient.GetIssue(selectedIssue.Key)
-			if err != nil {
-				return fmt.Errorf("failed to fetch task details: %w", err)
-			}
-			selectedIssue = issue
+	// Mirror the task in the Slack status, if enabled
+	setLoggedTaskStatus(cfg, store, selectedIssue)
+
+	// Show today's summary
+	fmt.Println()
+	printTodaySummary(store, jiraClient, tempoClient, cfg)
+
+	return nil
+}
+
+// selectIssue fetches the task with the given key, or lets the user pick one of their in-progress tasks or search for one.
+// A new task is created right away, for commands that start working on it immediately
+func selectIssue(jiraClient *jira.Client, cfg *config.Config, key string) (*jira.Issue, error) {
+	issue, draft, err := selectIssueOrDraft(jiraClient, cfg, key)
+	if err != nil || draft == nil {
+		return issue, err
+	}
+	return createIssue(jiraClient, draft)
+}
+
+// selectIssueOrDraft is selectIssue, but returns a draft instead of creating a new task, so it can be created
+// only once the time logged to it is confirmed
+func selectIssueOrDraft(jiraClient *jira.Client, cfg *config.Config, key string) (*jira.Issue, *jira.IssueDraft, error) {
+	if key != "" {
+		log.Debug().Str("task", key).Msg("Fetching specified task")
+		issue, err := jiraClient.GetIssue(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch task %s: %w", key, err)
 		}
+		fmt.Printf("Task: %s - %s\n", issue.Key, issue.Fields.Summary)
+		return issue, nil, nil
+	}
+
+	// Interactive task selection
+	log.Debug().Msg("Fetching in-progress tasks")
+	inProgressIssues, err := jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch in-progress tasks: %w", err)
+	}
+
+	issue, err := ui.SelectTask(inProgressIssues)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to select task: %w", err)
+	}
+
+	// Unplanned work gets a new issue
+	if issue.Key == "" {
+		draft, err := completeDraft(jiraClient, cfg, jira.IssueDraft{})
+		return nil, draft, err
 	}
 
-	// Get time spent
-	if timeSpent != "" {
-		timeSeconds, err = timeparse.Parse(timeSpent)
+	// If user chose to search, perform the search
+	if issue.Fields.Summary == "" {
+		searchResults, err := jiraClient.SearchIssues(issue.Key)
 		if err != nil {
-			return fmt.Errorf("invalid time format: %w", err)
+			return nil, nil, fmt.Errorf("failed to search tasks: %w", err)
 		}
-	} else {
-		timeStr, err := ui.PromptTimeSpent()
+
+		issue, err = ui.SelectFromSearchResults(searchResults)
 		if err != nil {
-			return fmt.Errorf("failed to get time spent: %w", err)
+			return nil, nil, fmt.Errorf("failed to select from search results: %w", err)
 		}
 
-		timeSeconds, err = timeparse.Parse(timeStr)
+		// Fetch full issue details
+		issue, err = jiraClient.GetIssue(issue.Key)
 		if err != nil {
-			return fmt.Errorf("invalid time format: %w", err)
+			return nil, nil, fmt.Errorf("failed to fetch task details: %w", err)
 		}
 	}
 
-	// Get label
+	return issue, nil, nil
+}
+
+// selectWorkLabel validates the given label, or prompts for one when it is empty
+func selectWorkLabel(cfg *config.Config, label string) (string, error) {
 	if label != "" {
 		if !cfg.IsLabelAllowed(label) {
-			return fmt.Errorf("label '%s' is not in the allowed labels list", label)
+			return "", fmt.Errorf("label '%s' is not in the allowed labels list", label)
 		}
-		selectedLabel = label
-	} else {
-		selectedLabel, err = ui.SelectLabel(cfg.Labels.AllowedLabels)
-		if err != nil {
-			return fmt.Errorf("failed to select label: %w", err)
+		return label, nil
+	}
+
+	selectedLabel, err := ui.SelectLabel(cfg.Labels.AllowedLabels)
+	if err != nil {
+		return "", fmt.Errorf("failed to select label: %w", err)
+	}
+
+	if !cfg.IsLabelAllowed(selectedLabel) {
+		return "", fmt.Errorf("label '%s' is not allowed", selectedLabel)
+	}
+	return selectedLabel, nil
+}
+
+// noTransition is the --transition value that skips the transition question
+const noTransition = "none"
+
+// selectTransition returns the transition named by --transition (or the shortcut), or asks which one to make
+// A nil transition keeps the issue's status
+func selectTransition(jiraClient *jira.Client, issue *jira.Issue, name string) (*jira.Transition, error) {
+	if strings.EqualFold(name, noTransition) {
+		return nil, nil
+	}
+
+	transitions, err := jiraClient.GetTransitions(issue.Key)
+	if err != nil {
+		if name != "" {
+			return nil, err
 		}
+		log.Warn().Err(err).Msg("Failed to fetch transitions")
+		return nil, nil
+	}
 
-		if !cfg.IsLabelAllowed(selectedLabel) {
-			return fmt.Errorf("label '%s' is not allowed", selectedLabel)
+	if name != "" {
+		transition, found := jira.FindTransition(transitions, name)
+		if !found {
+			return nil, fmt.Errorf("%s can't be transitioned to %q (available: %s)", issue.Key, name, transitionNames(transitions))
 		}
+		return transition, nil
 	}
 
-	// Get optional comment
-	comment, err := ui.PromptComment()
+	if len(transitions) == 0 {
+		return nil, nil
+	}
+	transition, err := ui.SelectTransition(issue.Fields.Status.Name, transitions)
 	if err != nil {
-		return fmt.Errorf("failed to get comment: %w", err)
+		return nil, fmt.Errorf("failed to select transition: %w", err)
 	}
+	return transition, nil
+}
 
-	// Confirm before logging
-	fmt.Printf("\n")
-	fmt.Printf("Task:    %s - %s\n", selectedIssue.Key, selectedIssue.Fields.Summary)
-	fmt.Printf("Time:    %s\n", timeparse.Format(timeSeconds))
-	fmt.Printf("Label:   %s\n", selectedLabel)
-	if comment != "" {
-		fmt.Printf("Comment: %s\n", comment)
+// selectDraftTransition is selectTransition for a task that isn't created yet. It offers the statuses of the
+// issue type's workflow; resolveTransition finds the transition leading there once the task exists
+func selectDraftTransition(jiraClient *jira.Client, draft *jira.IssueDraft, name string) (*jira.Transition, error) {
+	if strings.EqualFold(name, noTransition) {
+		return nil, nil
 	}
-	fmt.Printf("\n")
 
-	confirmed, err := ui.Confirm("Log this time entry?")
+	statuses, err := jiraClient.GetIssueTypeStatuses(draft.ProjectKey, draft.IssueType)
 	if err != nil {
-		return fmt.Errorf("failed to confirm: %w", err)
+		if name != "" {
+			return nil, err
+		}
+		log.Warn().Err(err).Msg("Failed to fetch statuses")
+		return nil, nil
 	}
 
-	if !confirmed {
-		fmt.Println("Cancelled.")
-		return nil
+	targets := make([]jira.Transition, 0, len(statuses))
+	for _, status := range statuses {
+		targets = append(targets, jira.Transition{Name: status, To: jira.IssueStatus{Name: status}})
 	}
 
-	// Create time entry
-	now := time.Now()
-	entry := &storage.TimeEntry{
-		IssueKey:         selectedIssue.Key,
-		IssueSummary:     selectedIssue.Fields.Summary,
-		TimeSpentSeconds: timeSeconds,
-		TimeSpent:        timeparse.Format(timeSeconds),
-		Label:            selectedLabel,
-		Comment:          comment,
-		Started:          now,
-		SyncedToJira:     false,
-		SyncedToTempo:    false,
+	if name != "" {
+		target, found := jira.FindTransition(targets, name)
+		if !found {
+			return nil, fmt.Errorf("a new %s can't be moved to %q (statuses: %s)", draft.IssueType, name, transitionNames(targets))
+		}
+		return target, nil
+	}
+
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	target, err := ui.SelectTransition("", targets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select transition: %w", err)
+	}
+	return target, nil
+}
+
+// resolveTransition returns the transition of a new task leading to the status picked by selectDraftTransition.
+// Transitions from selectTransition are returned as they are
+func resolveTransition(jiraClient *jira.Client, issue *jira.Issue, target *jira.Transition) (*jira.Transition, error) {
+	if target.ID != "" {
+		return target, nil
+	}
+
+	transitions, err := jiraClient.GetTransitions(issue.Key)
+	if err != nil {
+		return nil, err
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, target.To.Name) {
+			return &transitions[i], nil
+		}
 	}
+	return nil, fmt.Errorf("%s can't be transitioned to %s from its first status (available: %s)", issue.Key, target.To.Name, transitionNames(transitions))
+}
 
+// transitionNames lists transition names for error messages
+func transitionNames(transitions []jira.Transition) string {
+	if len(transitions) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(transitions))
+	for _, transition := range transitions {
+		names = append(names, transition.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// transitionTarget returns the status a transition leads to, or its name when Jira doesn't say
+func transitionTarget(transition *jira.Transition) string {
+	if transition.To.Name != "" {
+		return transition.To.Name
+	}
+	return transition.Name
+}
+
+// promptComment asks for the worklog comment, in $EDITOR with --editor
+func promptComment() (string, error) {
+	if useEditor {
+		return ui.PromptCommentEditor()
+	}
+	return ui.PromptComment()
+}
+
+// indentComment aligns the lines of a multi-line comment under the first one in the confirmation
+func indentComment(comment string) string {
+	return strings.ReplaceAll(comment, "\n", "\n         ")
+}
+
+// promptTimeEntry parses the --time value, or asks for the time spent when it's empty
+func promptTimeEntry(cfg *config.Config, input string, now time.Time) (timeparse.Entry, error) {
+	rules := cfg.Time.TimeRules()
+	if input == "" {
+		var err error
+		input, err = ui.PromptTimeSpent(rules.Describe())
+		if err != nil {
+			return timeparse.Entry{}, fmt.Errorf("failed to get time spent: %w", err)
+		}
+	}
+
+	entry, err := rules.ParseEntry(input, now)
+	if err != nil {
+		return timeparse.Entry{}, fmt.Errorf("invalid time format: %w", err)
+	}
+	return entry, nil
+}
+
+// saveTimeEntry stores an entry locally, logs it to Jira and records the sync status
+// Jira failures are reported but the entry stays in the local cache for 'tasklog sync'
+func saveTimeEntry(store *storage.Storage, jiraClient *jira.Client, cfg *config.Config, entry *storage.TimeEntry) error {
 	// Save to local storage first
 	if err := store.AddTimeEntry(entry); err != nil {
 		return fmt.Errorf("failed to save time entry locally: %w", err)
@@ -247,7 +551,7 @@ func runLog(cmd *cobra.Command, args []string) error {
 
 	// Log to Jira
 	log.Debug().Msg("Logging to Jira")
-	worklog, err := jiraClient.AddWorklog(selectedIssue.Key, timeSeconds, now, comment)
+	worklog, err := jiraClient.AddWorklog(entry.IssueKey, entry.TimeSpentSeconds, entry.Started, entry.Comment, entryEstimate(cfg, entry))
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to log to Jira")
 		fmt.Printf("⚠ Failed to log to Jira: %v\n", err)
@@ -274,9 +578,12 @@ func runLog(cmd *cobra.Command, args []string) error {
 		log.Error().Err(err).Msg("Failed to update time entry sync status")
 	}
 
-	// Show today's summary
-	fmt.Println()
-	if cfg.Tempo.Enabled && cfg.Tempo.APIToken != "" {
+	return nil
+}
+
+// printTodaySummary shows today's summary, or explains how to enable it when Tempo isn't configured
+func printTodaySummary(store *storage.Storage, jiraClient *jira.Client, tempoClient *tempo.Client, cfg *config.Config) {
+	if cfg.UsesTempo() {
 		if err := showTodaySummary(store, jiraClient, tempoClient, cfg); err != nil {
 			log.Error().Err(err).Msg("Failed to show summary")
 		}
@@ -290,8 +597,6 @@ func runLog(cmd *cobra.Command, args []string) error {
 		fmt.Println("    api_token: \"your-tempo-api-token\"")
 		fmt.Println("═══════════════════════════════════════════")
 	}
-
-	return nil
 }
 
 func showTodaySummary(store *storage.Storage, jiraClient *jira.Client, tempoClient *tempo.Client, cfg *config.Config) error {
@@ -374,7 +679,37 @@ func showTodaySummary(store *storage.Storage, jiraClient *jira.Client, tempoClie
 		}
 	}
 
+	// Display breaks so break time can be compared with work time
+	breaks, err := store.GetTodayBreaks()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get today's breaks")
+	}
+	now := time.Now()
+	breakTotal := 0
+	for _, b := range breaks {
+		breakTotal += int(b.Duration(now).Seconds())
+	}
+
+	fmt.Printf("\n☕ Breaks (%d): %s\n", len(breaks), timeparse.Format(breakTotal))
+	for _, b := range breaks {
+		breakInfo := ""
+		if b.IsActive(now) {
+			breakInfo = " (in progress)"
+		} else if b.EndedAt != nil && b.EndedAt.Before(b.PlannedEnd) {
+			breakInfo = " (ended early)"
+		}
+
+		fmt.Printf("  %s-%s - %-10s %s%s\n",
+			b.StartedAt.Format("15:04"),
+			b.End(now).Format("15:04"),
+			timeparse.Format(int(b.Duration(now).Seconds())),
+			b.Name,
+			breakInfo,
+		)
+	}
+
 	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Printf("⏱️  Work: %s | Breaks: %s\n", timeparse.Format(tempoTotal), timeparse.Format(breakTotal))
 
 	// Show comparison between Tempo and local data
 	if len(localEntries) > 0 {
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tasklog/internal/jira"
+)
+
+func TestSelectTransition(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transitions":[{"id":"21","name":"Start review","to":{"name":"In Review"}},{"id":"31","name":"Done","to":{"name":"Done"}}]}`))
+	}))
+	defer server.Close()
+
+	client := jira.NewClient(server.URL, "user@example.com", "token", "PROJ")
+	issue := &jira.Issue{Key: "PROJ-1"}
+
+	transition, err := selectTransition(client, issue, "in review")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transition == nil || transition.ID != "21" || transitionTarget(transition) != "In Review" {
+		t.Errorf("expected the Start review transition, got %+v", transition)
+	}
+
+	_, err = selectTransition(client, issue, "Closed")
+	if err == nil || !strings.Contains(err.Error(), "available: Start review, Done") {
+		t.Errorf("expected an error listing the transitions, got %v", err)
+	}
+
+	requests = 0
+	transition, err = selectTransition(client, issue, "None")
+	if err != nil || transition != nil {
+		t.Errorf("expected no transition for none, got %+v, %v", transition, err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request for none, got %d", requests)
+	}
+}
+
+func TestSelectDraftTransition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/statuses") {
+			w.Write([]byte(`[{"name":"Task","statuses":[{"name":"To Do"},{"name":"In Review"},{"name":"Done"}]}]`))
+			return
+		}
+		w.Write([]byte(`{"transitions":[{"id":"21","name":"Start review","to":{"name":"In Review"}}]}`))
+	}))
+	defer server.Close()
+
+	client := jira.NewClient(server.URL, "user@example.com", "token", "PROJ")
+	draft := &jira.IssueDraft{ProjectKey: "PROJ", IssueType: "Task"}
+
+	target, err := selectDraftTransition(client, draft, "in review")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target == nil || target.ID != "" || transitionTarget(target) != "In Review" {
+		t.Fatalf("expected the In Review status, got %+v", target)
+	}
+
+	_, err = selectDraftTransition(client, draft, "Review")
+	if err == nil || !strings.Contains(err.Error(), "statuses: To Do, In Review, Done") {
+		t.Errorf("expected an error listing the statuses, got %v", err)
+	}
+
+	// Once created, the status is reached through the transition leading there
+	issue := &jira.Issue{Key: "PROJ-7"}
+	transition, err := resolveTransition(client, issue, target)
+	if err != nil || transition.ID != "21" {
+		t.Errorf("expected the Start review transition, got %+v, %v", transition, err)
+	}
+
+	_, err = resolveTransition(client, issue, &jira.Transition{Name: "Done", To: jira.IssueStatus{Name: "Done"}})
+	if err == nil {
+		t.Error("expected an error when no transition leads to the status")
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/oauth"
+	"tasklog/internal/ui"
+)
+
+// Names of the tokens kept in the token store
+const (
+	jiraTokenName  = "jira"
+	tempoTokenName = "tempo"
+)
+
+var loginNoBrowser bool
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Sign in to Jira and Tempo with OAuth 2.0",
+	Long: `Signs in with the OAuth 2.0 apps configured under 'oauth', instead of API tokens.
+
+Opens the browser to authorize tasklog and receives the answer on
+http://localhost:<oauth.redirect_port>/callback, using PKCE. The refresh tokens are
+stored in tokens.json next to the config file, readable only by you, and access
+tokens are refreshed automatically. Run 'tasklog logout' to remove them.
+
+Tempo is signed in too when tempo.enabled and oauth.tempo_client_id are set.
+
+Examples:
+  tasklog login
+  tasklog login --no-browser   # Print the link instead of opening it` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runLogin,
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the tokens stored by 'tasklog login'",
+	Args:  cobra.NoArgs,
+	RunE:  runLogout,
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+
+	loginCmd.Flags().BoolVar(&loginNoBrowser, "no-browser", false, "Print the authorization link instead of opening the browser")
+}
+
+func runLogin(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.OAuth.Enabled() {
+		return fmt.Errorf("oauth.client_id is not set; create an OAuth 2.0 (3LO) app at https://developer.atlassian.com/console/myapps/ " +
+			"with the callback URL http://localhost:<oauth.redirect_port>/callback and add it to the config")
+	}
+
+	store := tokenStore()
+
+	token, err := oauth.Login(jiraProvider(cfg), openAuthURL("Jira"))
+	if err != nil {
+		return fmt.Errorf("failed to log in to Jira: %w", err)
+	}
+	cloudID, err := oauth.AtlassianCloudID(token.AccessToken, cfg.Jira.URL)
+	if err != nil {
+		return err
+	}
+	token.CloudID = cloudID
+	if err := store.Save(jiraTokenName, token); err != nil {
+		return err
+	}
+
+	user, err := newJiraClient(cfg).GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("logged in, but Jira rejected the token: %w", err)
+	}
+	fmt.Printf("✓ Logged in to Jira as %s\n", user.DisplayName)
+
+	if cfg.Tempo.Enabled && cfg.OAuth.TempoEnabled() {
+		fmt.Println()
+		token, err := oauth.Login(tempoProvider(cfg), openAuthURL("Tempo"))
+		if err != nil {
+			return fmt.Errorf("failed to log in to Tempo: %w", err)
+		}
+		if err := store.Save(tempoTokenName, token); err != nil {
+			return err
+		}
+
+		if _, err := newTempoClient(cfg).GetTodayWorklogs(user.AccountID); err != nil {
+			return fmt.Errorf("logged in, but Tempo rejected the token: %w", err)
+		}
+		fmt.Println("✓ Logged in to Tempo")
+	}
+
+	fmt.Printf("\nTokens saved to %s\n", store.Path())
+	return nil
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	store := tokenStore()
+
+	loggedOut := false
+	for _, name := range []string{jiraTokenName, tempoTokenName} {
+		deleted, err := store.Delete(name)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			loggedOut = true
+			fmt.Printf("✓ Removed %s token\n", name)
+		}
+	}
+
+	if !loggedOut {
+		fmt.Println("Not logged in.")
+		return nil
+	}
+	fmt.Println("💡 To revoke access on Atlassian's side too, remove the app at https://id.atlassian.com/manage-profile/apps")
+	return nil
+}
+
+// openAuthURL returns a callback that shows the authorization link and opens it unless --no-browser is set
+func openAuthURL(name string) func(string) {
+	return func(authURL string) {
+		fmt.Printf("🔐 Authorize tasklog for %s:\n   %s\n", name, authURL)
+		if !loginNoBrowser {
+			if err := ui.OpenBrowser(authURL); err != nil {
+				fmt.Printf("⚠️  Could not open a browser (%v), open the link above\n", err)
+			}
+		}
+		fmt.Println("Waiting for authorization...")
+	}
+}
+
+// tokenStore returns the store for tokens from 'tasklog login', next to the config file
+func tokenStore() *oauth.Store {
+	// GetConfigDir only fails without a home directory, which config loading already rejects
+	configDir, _ := config.GetConfigDir()
+	return oauth.NewStore(filepath.Join(configDir, "tokens.json"))
+}
+
+// tokenSources holds one token source per token name, so all clients in a process share a token and
+// refresh it once
+var (
+	tokenSourcesMu sync.Mutex
+	tokenSources   = make(map[string]*oauth.TokenSource)
+)
+
+// tokenSource returns the process-wide token source for the named token from 'tasklog login'
+func tokenSource(name string, provider oauth.Provider) *oauth.TokenSource {
+	tokenSourcesMu.Lock()
+	defer tokenSourcesMu.Unlock()
+
+	if source, ok := tokenSources[name]; ok {
+		return source
+	}
+	source := oauth.NewTokenSource(provider, tokenStore(), name)
+	tokenSources[name] = source
+	return source
+}
+
+// oauthRedirectURI is the loopback callback registered with the OAuth apps
+func oauthRedirectURI(cfg *config.Config) string {
+	return fmt.Sprintf("http://localhost:%d/callback", cfg.OAuth.RedirectPort)
+}
+
+// jiraProvider returns the configured Atlassian OAuth app
+func jiraProvider(cfg *config.Config) oauth.Provider {
+	return oauth.Atlassian(cfg.OAuth.ClientID, cfg.OAuth.ClientSecret, oauthRedirectURI(cfg))
+}
+
+// tempoProvider returns the configured Tempo OAuth app
+func tempoProvider(cfg *config.Config) oauth.Provider {
+	return oauth.Tempo(cfg.Jira.URL, cfg.OAuth.TempoClientID, cfg.OAuth.TempoClientSecret, oauthRedirectURI(cfg))
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/policy"
+	"tasklog/internal/storage"
+)
+
+// enforcePolicy explains every policy rule the worklog breaks and returns an error if it breaks any
+func enforcePolicy(cfg *config.Config, w policy.Worklog) error {
+	violations := policy.New(cfg.Policy).Check(w)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	fmt.Printf("❌ The worklog for %s doesn't meet the policy:\n", w.IssueKey)
+	for _, violation := range violations {
+		fmt.Printf("   - %s\n", violation)
+	}
+	return fmt.Errorf("worklog for %s breaks %d policy rule(s)", w.IssueKey, len(violations))
+}
+
+// entryPolicyWorklog describes a time entry for the policy, fetching the issue type from Jira when the policy needs it
+func entryPolicyWorklog(cfg *config.Config, jiraClient *jira.Client, entry *storage.TimeEntry) (policy.Worklog, error) {
+	w := policy.Worklog{
+		IssueKey: entry.IssueKey,
+		Project:  policy.ProjectKey(entry.IssueKey),
+		Label:    entry.Label,
+		Comment:  entry.Comment,
+		Seconds:  entry.TimeSpentSeconds,
+	}
+	if policy.New(cfg.Policy).NeedsIssueType() {
+		issue, err := jiraClient.GetIssue(entry.IssueKey)
+		if err != nil {
+			return w, fmt.Errorf("failed to fetch the issue type for the policy: %w", err)
+		}
+		w.IssueType = issue.Fields.IssueType.Name
+	}
+	return w, nil
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/pomodoro"
+	"tasklog/internal/scheduler"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
+)
+
+// pomodoroBreakEmoji is the Slack status emoji for long breaks without a configured break
+const pomodoroBreakEmoji = ":tomato:"
+
+var (
+	pomodoroCycles int
+	pomodoroLabel  string
+)
+
+var pomodoroCmd = &cobra.Command{
+	Use:   "pomodoro [task-key]",
+	Short: "Work on a task in pomodoro intervals and log the time",
+	Long: `Runs pomodoro cycles for a Jira task: work intervals separated by short breaks,
+with a long break after every few intervals. Each change of phase rings the terminal
+bell and shows a desktop notification.
+
+Long breaks go through the normal break flow (Slack status, channel message, snooze and
+presence), using the break named in pomodoro.break if set.
+
+The time of all completed work intervals is logged as one worklog when the last cycle
+finishes or when you press Ctrl+C. An interrupted work interval is not counted.
+Progress is saved after every interval, so if the time can't be logged (e.g., the
+terminal was closed), the next 'tasklog pomodoro' offers to log it.
+
+Interval lengths are configured in the pomodoro section (defaults: 25m work, 5m short
+break, 15m long break every 4 intervals).
+
+Examples:
+  tasklog pomodoro                  # Select from in-progress tasks, run until Ctrl+C
+  tasklog pomodoro PROJ-123 -n 4    # Four work intervals, then log the time
+  tasklog pomodoro PROJ-123 -l development` + configHelp,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPomodoro,
+}
+
+func init() {
+	rootCmd.AddCommand(pomodoroCmd)
+
+	pomodoroCmd.Flags().IntVarP(&pomodoroCycles, "cycles", "n", 0, "Number of work intervals (0 runs until Ctrl+C)")
+	pomodoroCmd.Flags().StringVarP(&pomodoroLabel, "label", "l", "", "Work log label")
+}
+
+func runPomodoro(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+	if pomodoroCycles < 0 {
+		return fmt.Errorf("--cycles must not be negative")
+	}
+
+	key := ""
+	if len(args) > 0 {
+		key = args[0]
+	}
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	jiraClient := newJiraClient(cfg)
+	if err := logUnfinishedPomodoro(cfg, store, jiraClient); err != nil {
+		return err
+	}
+
+	issue, err := selectIssue(jiraClient, cfg, key)
+	if err != nil {
+		return err
+	}
+
+	plan := pomodoro.NewPlan(cfg.Pomodoro)
+	longBreak := pomodoroLongBreak(cfg)
+
+	fmt.Printf("🍅 Pomodoro for %s - %s (%s work, %s short break, %s long break every %d)\n",
+		issue.Key, issue.Fields.Summary,
+		timeparse.Format(int(plan.Work.Seconds())), timeparse.Format(int(plan.ShortBreak.Seconds())),
+		timeparse.Format(int(plan.LongBreak.Seconds())), plan.LongBreakEvery)
+	fmt.Println("Press Ctrl+C to stop and log the completed intervals.")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var session pomodoro.Session
+	for pomodoroCycles == 0 || session.Completed < pomodoroCycles {
+		start := time.Now()
+		end := start.Add(plan.Work)
+		fmt.Printf("\n🍅 Pomodoro %d: working until %s\n", session.Completed+1, end.Format("15:04"))
+		if !waitUntil(ctx, end) {
+			fmt.Printf("\n⏹️  Stopped, the interrupted interval (%s) is not counted\n", timeparse.Format(int(time.Since(start).Seconds())))
+			break
+		}
+		session.CompleteWork(start, plan.Work)
+		savePomodoroProgress(store, issue, session)
+
+		if pomodoroCycles != 0 && session.Completed == pomodoroCycles {
+			scheduler.Notify("Pomodoro done", fmt.Sprintf("%d intervals on %s", session.Completed, issue.Key))
+			fmt.Printf("✓ Pomodoro %d done, all intervals finished\n", session.Completed)
+			break
+		}
+
+		phase := plan.BreakAfter(session.Completed)
+		breakEnd := time.Now().Add(plan.Duration(phase))
+		scheduler.Notify("Pomodoro", fmt.Sprintf("Time for a %s until %s", phase, breakEnd.Format("15:04")))
+		fmt.Printf("✓ Pomodoro %d done (%s worked), %s until %s\n",
+			session.Completed, timeparse.Format(session.WorkedSeconds()), phase, breakEnd.Format("15:04"))
+
+		var recorded *storage.Break
+		if phase == pomodoro.PhaseLongBreak {
+			recorded = startBreak(cfg, longBreak)
+		}
+		completed := waitUntil(ctx, breakEnd)
+		if phase == pomodoro.PhaseLongBreak {
+			// End it like 'tasklog back' would, so the status and break message don't linger
+			if recorded != nil {
+				if err := endBreak(cfg, store, recorded, longBreak, time.Now()); err != nil {
+					log.Warn().Err(err).Msg("Failed to end the long break")
+				}
+			} else {
+				resumeSlack(cfg, longBreak.Snooze, longBreak.Away)
+			}
+		}
+		if !completed {
+			fmt.Println("\n⏹️  Stopped during the break")
+			break
+		}
+		scheduler.Notify("Pomodoro", "Back to work")
+	}
+	stop()
+
+	if session.Completed == 0 {
+		fmt.Println("ℹ️  No completed work intervals, nothing to log")
+		return nil
+	}
+
+	fmt.Printf("\n⏱️  %s - %s: %d intervals, %s\n", issue.Key, issue.Fields.Summary,
+		session.Completed, timeparse.Format(session.WorkedSeconds()))
+
+	if err := savePomodoroWork(store, jiraClient, cfg, issue.Key, issue.Fields.Summary, session.Started, pomodoroLabel, session.WorkedSeconds()); err != nil {
+		fmt.Println("💡 The intervals are kept; the next 'tasklog pomodoro' offers to log them")
+		return err
+	}
+	if err := store.ClearPomodoroSession(); err != nil {
+		log.Warn().Err(err).Msg("Failed to clear the logged pomodoro session")
+	}
+
+	fmt.Println()
+	printTodaySummary(store, jiraClient, newTempoClient(cfg), cfg)
+	return nil
+}
+
+// savePomodoroWork logs the worked time of a pomodoro run as one worklog from the start of the run. The breaks
+// in between are left out of the time, so the start is only approximate and the worklog isn't checked for overlaps
+func savePomodoroWork(store *storage.Storage, jiraClient *jira.Client, cfg *config.Config, issueKey, issueSummary string, started time.Time, label string, timeSeconds int) error {
+	item := workItem{IssueKey: issueKey, IssueSummary: issueSummary, Spans: []timeSpan{{Start: started, Seconds: timeSeconds, Approximate: true}}}
+	_, err := saveWorkItems(store, jiraClient, cfg, []workItem{item}, label, false)
+	return err
+}
+
+// savePomodoroProgress saves the completed intervals, so they can still be logged if this run can't log them
+func savePomodoroProgress(store *storage.Storage, issue *jira.Issue, session pomodoro.Session) {
+	err := store.SavePomodoroSession(&storage.PomodoroSession{
+		IssueKey:      issue.Key,
+		IssueSummary:  issue.Fields.Summary,
+		StartedAt:     session.Started,
+		Completed:     session.Completed,
+		WorkedSeconds: session.WorkedSeconds(),
+		Label:         pomodoroLabel,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to save pomodoro progress")
+	}
+}
+
+// logUnfinishedPomodoro offers to log the intervals of an earlier pomodoro run that ended without logging them
+func logUnfinishedPomodoro(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client) error {
+	unfinished, err := store.GetPomodoroSession()
+	if err != nil {
+		return err
+	}
+	if unfinished == nil {
+		return nil
+	}
+
+	fmt.Printf("⚠️  A pomodoro on %s - %s from %s wasn't logged: %d intervals, %s\n",
+		unfinished.IssueKey, unfinished.IssueSummary, unfinished.StartedAt.Format("Mon 15:04"),
+		unfinished.Completed, timeparse.Format(unfinished.WorkedSeconds))
+	confirmed, err := ui.Confirm("Log it now?")
+	if err != nil {
+		return fmt.Errorf("failed to confirm: %w", err)
+	}
+
+	if confirmed {
+		err := savePomodoroWork(store, jiraClient, cfg, unfinished.IssueKey, unfinished.IssueSummary, unfinished.StartedAt, unfinished.Label, unfinished.WorkedSeconds)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("Discarded.")
+	}
+	fmt.Println()
+	return store.ClearPomodoroSession()
+}
+
+// pomodoroLongBreak returns the break used for long breaks: the configured break with the
+// pomodoro long break length, or a plain "pomodoro" break
+func pomodoroLongBreak(cfg *config.Config) *config.BreakEntry {
+	if cfg.Pomodoro.Break != "" {
+		if configured, found := cfg.GetBreak(cfg.Pomodoro.Break); found {
+			longBreak := *configured
+			longBreak.Duration = cfg.Pomodoro.LongBreak
+			return &longBreak
+		}
+	}
+	return &config.BreakEntry{
+		Name:     "pomodoro",
+		Duration: cfg.Pomodoro.LongBreak,
+		Emoji:    pomodoroBreakEmoji,
+	}
+}
+
+// waitUntil blocks until the given time and reports whether it was reached before ctx was cancelled
+func waitUntil(ctx context.Context, end time.Time) bool {
+	timer := time.NewTimer(time.Until(end))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+)
+
+func TestPomodoroLongBreak(t *testing.T) {
+	cfg := &config.Config{
+		Slack: config.SlackConfig{
+			Breaks: []config.BreakEntry{{Name: "coffee", Duration: 10, Emoji: ":coffee:", Away: true}},
+		},
+		Pomodoro: config.PomodoroConfig{LongBreak: 15},
+	}
+
+	plain := pomodoroLongBreak(cfg)
+	if plain.Name != "pomodoro" || plain.Duration != 15 || plain.Emoji != pomodoroBreakEmoji {
+		t.Errorf("unexpected default long break: %+v", plain)
+	}
+
+	cfg.Pomodoro.Break = "coffee"
+	configured := pomodoroLongBreak(cfg)
+	if configured.Name != "coffee" || configured.Duration != 15 || !configured.Away {
+		t.Errorf("expected coffee break with pomodoro length, got %+v", configured)
+	}
+	if cfg.Slack.Breaks[0].Duration != 10 {
+		t.Error("expected configured break to be left unchanged")
+	}
+}
+
+func TestWaitUntil(t *testing.T) {
+	if !waitUntil(context.Background(), time.Now().Add(10*time.Millisecond)) {
+		t.Error("expected wait to reach the end time")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if waitUntil(ctx, time.Now().Add(time.Hour)) {
+		t.Error("expected cancelled wait to return false")
+	}
+}
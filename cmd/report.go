@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/report"
+	"tasklog/internal/slack"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+var (
+	reportDay    bool
+	reportWeek   bool
+	reportDate   string
+	reportThread string
+	reportDryRun bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Worklog reports",
+	Long:  `Build reports from the time entries logged with tasklog.`,
+}
+
+var reportPostCmd = &cobra.Command{
+	Use:   "post",
+	Short: "Post a daily or weekly worklog digest to Slack",
+	Long: `Posts a summary of the time logged with tasklog to Slack, with a per-issue
+table, the total and the gap to your target (report.daily_target, default 8h;
+five times that for a week).
+
+The digest is posted to report.channel_id, or slack.channel_id if not set.
+
+Examples:
+  tasklog report post                      # Today (same as --day)
+  tasklog report post --date yesterday     # Yesterday, e.g. for standup
+  tasklog report post --week               # This week (Monday to Sunday)
+  tasklog report post --thread 1712345678.123456
+  tasklog report post --dry-run            # Print instead of posting` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runReportPost,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportPostCmd)
+
+	reportPostCmd.Flags().BoolVar(&reportDay, "day", false, "Report a single day (default)")
+	reportPostCmd.Flags().BoolVar(&reportWeek, "week", false, "Report the week containing --date")
+	reportPostCmd.Flags().StringVar(&reportDate, "date", "today", "Date to report: today, yesterday or YYYY-MM-DD")
+	reportPostCmd.Flags().StringVar(&reportThread, "thread", "", "Timestamp of a message to reply to in a thread")
+	reportPostCmd.Flags().BoolVar(&reportDryRun, "dry-run", false, "Print the report instead of posting it")
+	reportPostCmd.MarkFlagsMutuallyExclusive("day", "week")
+}
+
+func runReportPost(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	date, err := parseReportDate(reportDate, time.Now())
+	if err != nil {
+		return err
+	}
+
+	period := report.Day(date)
+	if reportWeek {
+		period = report.Week(date)
+	}
+
+	dailyTarget, err := timeparse.Parse(cfg.Report.DailyTarget)
+	if err != nil {
+		return fmt.Errorf("invalid report.daily_target: %w", err)
+	}
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	entries, err := store.GetEntriesBetween(period.From, period.To)
+	if err != nil {
+		return err
+	}
+
+	r := report.Build(entries, period, dailyTarget)
+
+	if reportDryRun {
+		fmt.Println(r.Text())
+		return nil
+	}
+
+	channelID := cfg.Report.ChannelID
+	if channelID == "" {
+		channelID = cfg.Slack.ChannelID
+	}
+	if cfg.Slack.UserToken == "" || channelID == "" {
+		return fmt.Errorf("slack is not configured; set slack.user_token and slack.channel_id (or report.channel_id), or use --dry-run")
+	}
+
+	slackClient := slack.NewClient(cfg.Slack.UserToken, channelID)
+	if _, err := slackClient.PostBlocks(r.Text(), r.Blocks(), reportThread); err != nil {
+		return fmt.Errorf("failed to post report to Slack: %w", err)
+	}
+
+	fmt.Printf("✅ Posted worklog for %s to Slack (%s, %s)\n", period.Title(), timeparse.Format(r.TotalSeconds), r.GapText())
+	return nil
+}
+
+// parseReportDate parses "today", "yesterday" or a YYYY-MM-DD date in the local timezone
+func parseReportDate(value string, now time.Time) (time.Time, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return now, nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected today, yesterday or YYYY-MM-DD)", value)
+	}
+	return date, nil
+}
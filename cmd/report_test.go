@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseReportDate(t *testing.T) {
+	now := time.Date(2025, 1, 6, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{input: "today", expected: now},
+		{input: "", expected: now},
+		{input: "Yesterday", expected: now.AddDate(0, 0, -1)},
+		{input: "2025-01-02", expected: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{input: "02/01/2025", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseReportDate(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReportDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.expected) {
+				t.Errorf("parseReportDate(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
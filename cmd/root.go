@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"tasklog/internal/config"
+	"tasklog/internal/jira"
 	"tasklog/internal/prerelease"
+	"tasklog/internal/tempo"
 	"tasklog/internal/updater"
 
 	"github.com/rs/zerolog/log"
@@ -109,6 +112,9 @@ func checkForUpdates() {
 }
 
 func checkConfig() (*config.Config, error) {
+	// Offer to upgrade outdated config files before loading
+	offerConfigMigration()
+
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
@@ -123,9 +129,31 @@ func checkConfig() (*config.Config, error) {
 		fmt.Fprintf(os.Stderr, "See config.example.yaml for an example configuration.\n")
 		return nil, err
 	}
+
+	// Breaks that ran out without 'tasklog back' would otherwise leave Slack presence on away
+	restoreExpiredBreaks(cfg, time.Now())
 	return cfg, nil
 }
 
+// newJiraClient creates a Jira client for the configured deployment and credentials
+func newJiraClient(cfg *config.Config) *jira.Client {
+	if cfg.OAuth.Enabled() {
+		return jira.NewOAuthClient(cfg.Jira.URL, tokenSource(jiraTokenName, jiraProvider(cfg)), cfg.Jira.ProjectKey)
+	}
+	if cfg.Jira.IsServer() {
+		return jira.NewServerClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
+	}
+	return jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
+}
+
+// newTempoClient creates a Tempo client with the configured API token or the Tempo OAuth app
+func newTempoClient(cfg *config.Config) *tempo.Client {
+	if cfg.OAuth.TempoEnabled() {
+		return tempo.NewOAuthClient(tokenSource(tempoTokenName, tempoProvider(cfg)))
+	}
+	return tempo.NewClient(cfg.Tempo.APIToken)
+}
+
 var (
 	version = "dev"
 	commit  = "none"
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/scheduler"
+	"tasklog/internal/storage"
+	"tasklog/internal/ui"
+)
+
+// scheduleTickInterval is how often the scheduler checks for due breaks
+const scheduleTickInterval = 15 * time.Second
+
+var (
+	scheduleSnooze    time.Duration
+	scheduleAutoStart bool
+)
+
+var breakScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Show scheduled breaks",
+	Long: `Lists breaks that have a schedule in the config file and when the next one is due.
+
+Add a schedule to a break in your config file:
+
+  slack:
+    breaks:
+      - name: "lunch"
+        duration: 60
+        schedule:
+          times: ["12:30"]               # Fixed start times (24h)
+          days: ["mon", "tue", "wed", "thu", "fri"]
+      - name: "prayer"
+        duration: 15
+        schedule:
+          window: "15:00-15:45"          # Reminded at the start, can be snoozed until the end
+
+Run 'tasklog break schedule run' to get reminders and start breaks automatically.` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runBreakScheduleList,
+}
+
+var breakScheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Remind and start scheduled breaks",
+	Long: `Runs in the foreground and, when a scheduled break is due:
+- Rings the terminal bell and shows a desktop notification (notify-send or osascript)
+- Asks whether to start the break now, snooze it or skip it
+- Starts the break exactly like 'tasklog break <name>'
+
+Breaks are not offered while another break is active. Snoozing is only offered
+while the break is still within its window (or its planned duration for fixed times).
+
+When not attached to a terminal, or with --yes, due breaks start without asking.
+
+Example:
+  tasklog break schedule run
+  tasklog break schedule run --snooze 10m
+  nohup tasklog break schedule run --yes &` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runBreakSchedule,
+}
+
+func init() {
+	breakCmd.AddCommand(breakScheduleCmd)
+	breakScheduleCmd.AddCommand(breakScheduleRunCmd)
+
+	breakScheduleRunCmd.Flags().DurationVar(&scheduleSnooze, "snooze", 5*time.Minute, "How long to snooze a reminder")
+	breakScheduleRunCmd.Flags().BoolVarP(&scheduleAutoStart, "yes", "y", false, "Start due breaks without asking")
+}
+
+func runBreakScheduleList(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	scheduled := 0
+	fmt.Println("📅 Scheduled breaks:")
+	fmt.Println("")
+	for _, b := range cfg.Slack.Breaks {
+		if b.Schedule == nil {
+			continue
+		}
+		scheduled++
+		fmt.Printf("  %-12s %-24s %s (%d minutes)\n", b.Name, describeScheduleTimes(b.Schedule), describeScheduleDays(b.Schedule), b.Duration)
+	}
+
+	if scheduled == 0 {
+		fmt.Println("  No breaks have a schedule. Run 'tasklog break schedule --help' for an example.")
+		return nil
+	}
+
+	if next := scheduler.Next(cfg.Slack.Breaks, time.Now()); next != nil {
+		fmt.Printf("\n⏭️  Next: %s break at %s\n", next.Break.Name, next.At.Format("Mon 15:04"))
+	}
+	fmt.Println("\nRun 'tasklog break schedule run' to get reminders.")
+	return nil
+}
+
+func runBreakSchedule(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	next := scheduler.Next(cfg.Slack.Breaks, time.Now())
+	if next == nil {
+		return fmt.Errorf("no breaks are scheduled in the next week; add a schedule to a break in your config (see 'tasklog break schedule --help')")
+	}
+
+	interactive := ui.IsInteractive() && !scheduleAutoStart
+
+	fmt.Println("🕒 Break scheduler running (press Ctrl+C to stop)")
+	fmt.Printf("⏭️  Next: %s break at %s\n", next.Break.Name, next.At.Format("Mon 15:04"))
+
+	// Windows that are already open when starting still get a reminder
+	pending := scheduler.Open(cfg.Slack.Breaks, time.Now())
+	from := time.Now()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(scheduleTickInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, occ := range pending {
+			if err := handleScheduledBreak(ctx, cfg, occ, interactive); err != nil {
+				if errors.Is(err, terminal.InterruptErr) || ctx.Err() != nil {
+					fmt.Println("\n👋 Break scheduler stopped")
+					return nil
+				}
+				return err
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			fmt.Println("\n👋 Break scheduler stopped")
+			return nil
+		}
+		now := time.Now()
+		restoreExpiredBreaks(cfg, now)
+		pending = scheduler.Due(cfg.Slack.Breaks, from, now)
+		from = now
+	}
+}
+
+// handleScheduledBreak reminds the user about a due break and starts, snoozes or skips it
+// A snooze ends early with ctx's error when ctx is cancelled
+func handleScheduledBreak(ctx context.Context, cfg *config.Config, occ scheduler.Occurrence, interactive bool) error {
+	for {
+		active, err := activeBreak(cfg)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to check for an active break")
+		}
+		if active != nil {
+			fmt.Printf("⏭️  Skipping scheduled %s break: already on a %s break\n", occ.Break.Name, active.Name)
+			return nil
+		}
+
+		message := fmt.Sprintf("Time for your %s break (%d minutes)", occ.Break.Name, occ.Break.Duration)
+		scheduler.Notify("tasklog", message)
+		fmt.Printf("\n🔔 %s %s\n", time.Now().Format("15:04"), message)
+
+		if !interactive {
+			startBreak(cfg, &occ.Break)
+			return nil
+		}
+
+		const startOption, skipOption = "Start now", "Skip"
+		options := []string{startOption}
+		if occ.CanSnooze(time.Now(), scheduleSnooze) {
+			options = append(options, fmt.Sprintf("Snooze %s", scheduleSnooze))
+		}
+		options = append(options, skipOption)
+
+		choice, err := ui.Select("What would you like to do?", options)
+		if err != nil {
+			return err
+		}
+
+		switch choice {
+		case startOption:
+			startBreak(cfg, &occ.Break)
+			return nil
+		case skipOption:
+			fmt.Printf("⏭️  Skipped %s break\n", occ.Break.Name)
+			return nil
+		default:
+			until := time.Now().Add(scheduleSnooze)
+			fmt.Printf("😴 Snoozed until %s\n", until.Format("15:04"))
+			if !waitUntil(ctx, until) {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// activeBreak returns the break currently in progress, or nil if there is none
+func activeBreak(cfg *config.Config) (*storage.Break, error) {
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	return store.GetActiveBreak(time.Now())
+}
+
+// describeScheduleTimes renders a schedule's fixed times and window (e.g., "12:30, 15:00-15:45")
+func describeScheduleTimes(schedule *config.BreakSchedule) string {
+	parts := append([]string{}, schedule.Times...)
+	if schedule.Window != "" {
+		parts = append(parts, schedule.Window)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeScheduleDays renders a schedule's weekdays (e.g., "mon, tue" or "every day")
+func describeScheduleDays(schedule *config.BreakSchedule) string {
+	if len(schedule.Days) == 0 {
+		return "every day"
+	}
+	return strings.Join(schedule.Days, ", ")
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/policy"
+	"tasklog/internal/storage"
+	"tasklog/internal/tempo"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
+)
+
+// splitPrompt is the --split value used when the flag is given without tasks
+const splitPrompt = "prompt"
+
+// runSplitLog logs one duration split across several tasks, with a single confirmation
+func runSplitLog(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, tempoClient *tempo.Client, spec string, adjust jira.EstimateAdjustment) error {
+	if taskKey != "" || shortcutName != "" {
+		return fmt.Errorf("--split can't be combined with --task or a shortcut")
+	}
+	if transitionTo != "" && !strings.EqualFold(transitionTo, noTransition) {
+		return fmt.Errorf("--transition can't be combined with --split")
+	}
+	if adjust.Mode == jira.EstimateNew || adjust.Mode == jira.EstimateManual {
+		return fmt.Errorf("--split only works with --estimate auto or leave")
+	}
+
+	// The total is optional with explicit parts; a range or "since" fixes when the parts start
+	rules := cfg.Time.TimeRules()
+	now := time.Now()
+	var total timeparse.Entry
+	if timeSpent != "" || spec == splitPrompt {
+		var err error
+		total, err = promptTimeEntry(cfg, timeSpent, now)
+		if err != nil {
+			return err
+		}
+	}
+	totalSeconds := total.Seconds
+
+	var parts []timeparse.SplitPart
+	issues := make(map[string]*jira.Issue)
+
+	if spec == splitPrompt {
+		var err error
+		parts, err = promptSplit(jiraClient, cfg, totalSeconds, issues)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		parts, err = rules.ParseSplit(spec)
+		if err != nil {
+			return fmt.Errorf("invalid --split: %w", err)
+		}
+		for _, part := range parts {
+			issue, err := jiraClient.GetIssue(part.Key)
+			if err != nil {
+				return fmt.Errorf("failed to fetch task %s: %w", part.Key, err)
+			}
+			issues[part.Key] = issue
+		}
+	}
+
+	parts, totalSeconds, err := timeparse.ResolveSplit(parts, totalSeconds)
+	if err != nil {
+		return fmt.Errorf("invalid --split: %w", err)
+	}
+
+	selectedLabel, err := selectWorkLabel(cfg, label)
+	if err != nil {
+		return err
+	}
+
+	comment, err := promptComment()
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	end := now
+	if !total.Started.IsZero() {
+		end = total.Started.Add(time.Duration(totalSeconds) * time.Second)
+	}
+	entries := splitEntries(parts, issues, selectedLabel, comment, end)
+	for _, entry := range entries {
+		if !adjust.IsAuto() {
+			entry.AdjustEstimate = adjust.String()
+		}
+		entry.StartExact = !total.Started.IsZero()
+		if err := enforcePolicy(cfg, policy.Worklog{
+			IssueKey:  entry.IssueKey,
+			Project:   policy.ProjectKey(entry.IssueKey),
+			IssueType: issues[entry.IssueKey].Fields.IssueType.Name,
+			Label:     entry.Label,
+			Comment:   entry.Comment,
+			Seconds:   entry.TimeSpentSeconds,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Confirm all parts at once
+	fmt.Printf("\n")
+	for _, entry := range entries {
+		fmt.Printf("%s  %-8s %s - %s\n", entry.Started.Format("15:04"), entry.TimeSpent, entry.IssueKey, entry.IssueSummary)
+	}
+	fmt.Printf("\n")
+	fmt.Printf("Total:   %s\n", timeparse.Format(totalSeconds))
+	fmt.Printf("Label:   %s\n", selectedLabel)
+	if comment != "" {
+		fmt.Printf("Comment: %s\n", indentComment(comment))
+	}
+	fmt.Printf("\n")
+
+	if err := checkWorklogs(cfg, store, entryWorklogs(entries), now); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if warning := estimateWarning(issues[entry.IssueKey].Fields.TimeTracking, entry.TimeSpentSeconds); warning != "" {
+			fmt.Printf("⚠️  %s: %s\n", entry.IssueKey, warning)
+		}
+	}
+
+	confirmed, err := ui.Confirm(fmt.Sprintf("Log these %d time entries?", len(entries)))
+	if err != nil {
+		return fmt.Errorf("failed to confirm: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	for _, entry := range entries {
+		fmt.Printf("\n%s (%s)\n", entry.IssueKey, entry.TimeSpent)
+		if err := saveTimeEntry(store, jiraClient, cfg, entry); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	printTodaySummary(store, jiraClient, tempoClient, cfg)
+	return nil
+}
+
+// promptSplit lets the user pick in-progress tasks and the time for each; the last task gets the rest
+// Selected issues are added to issues by key
+func promptSplit(jiraClient *jira.Client, cfg *config.Config, totalSeconds int, issues map[string]*jira.Issue) ([]timeparse.SplitPart, error) {
+	inProgress, err := jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch in-progress tasks: %w", err)
+	}
+	if len(inProgress) < 2 {
+		return nil, fmt.Errorf("at least two in-progress tasks are needed to split interactively; use --split PROJ-1=1h,PROJ-2=rest")
+	}
+
+	options := make([]string, 0, len(inProgress))
+	byOption := make(map[string]*jira.Issue)
+	for i := range inProgress {
+		option := fmt.Sprintf("%s - %s", inProgress[i].Key, inProgress[i].Fields.Summary)
+		options = append(options, option)
+		byOption[option] = &inProgress[i]
+	}
+
+	selected, err := ui.MultiSelect(fmt.Sprintf("Select the tasks to split %s across:", timeparse.Format(totalSeconds)), options, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select tasks: %w", err)
+	}
+	if len(selected) < 2 {
+		return nil, fmt.Errorf("select at least two tasks to split the time")
+	}
+
+	var parts []timeparse.SplitPart
+	remaining := totalSeconds
+	for i, option := range selected {
+		issue := byOption[option]
+		issues[issue.Key] = issue
+
+		if i == len(selected)-1 {
+			fmt.Printf("%s gets the rest (%s)\n", issue.Key, timeparse.Format(remaining))
+			parts = append(parts, timeparse.SplitPart{Key: issue.Key, Rest: true})
+			break
+		}
+
+		input, err := ui.PromptInput(fmt.Sprintf("Time for %s (%s left):", issue.Key, timeparse.Format(remaining)), "", true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get time for %s: %w", issue.Key, err)
+		}
+		seconds, err := cfg.Time.TimeRules().Parse(input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time for %s: %w", issue.Key, err)
+		}
+		remaining -= seconds
+		parts = append(parts, timeparse.SplitPart{Key: issue.Key, Seconds: seconds})
+	}
+
+	return parts, nil
+}
+
+// splitEntries builds one time entry per part with consecutive start times, the last one ending at end
+func splitEntries(parts []timeparse.SplitPart, issues map[string]*jira.Issue, label, comment string, end time.Time) []*storage.TimeEntry {
+	total := 0
+	for _, part := range parts {
+		total += part.Seconds
+	}
+
+	started := end.Add(-time.Duration(total) * time.Second)
+	entries := make([]*storage.TimeEntry, 0, len(parts))
+	for _, part := range parts {
+		summary := ""
+		if issue := issues[part.Key]; issue != nil {
+			summary = issue.Fields.Summary
+		}
+		entries = append(entries, &storage.TimeEntry{
+			IssueKey:         part.Key,
+			IssueSummary:     strings.TrimSpace(summary),
+			TimeSpentSeconds: part.Seconds,
+			TimeSpent:        timeparse.Format(part.Seconds),
+			Label:            label,
+			Comment:          comment,
+			Started:          started,
+		})
+		started = started.Add(time.Duration(part.Seconds) * time.Second)
+	}
+	return entries
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"tasklog/internal/jira"
+	"tasklog/internal/timeparse"
+)
+
+func TestSplitEntries(t *testing.T) {
+	end := time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC)
+	parts := []timeparse.SplitPart{
+		{Key: "PROJ-1", Seconds: 3600},
+		{Key: "PROJ-2", Seconds: 2700},
+		{Key: "PROJ-3", Seconds: 4500},
+	}
+	issues := map[string]*jira.Issue{
+		"PROJ-1": {Key: "PROJ-1", Fields: jira.IssueFields{Summary: "First"}},
+	}
+
+	entries := splitEntries(parts, issues, "Development", "pairing", end)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	wantStarts := []string{"14:00", "15:00", "15:45"}
+	for i, entry := range entries {
+		if got := entry.Started.Format("15:04"); got != wantStarts[i] {
+			t.Errorf("entry %d started at %s, want %s", i, got, wantStarts[i])
+		}
+		if entry.TimeSpentSeconds != parts[i].Seconds {
+			t.Errorf("entry %d has %d seconds, want %d", i, entry.TimeSpentSeconds, parts[i].Seconds)
+		}
+		if entry.Label != "Development" || entry.Comment != "pairing" {
+			t.Errorf("entry %d has label %q and comment %q", i, entry.Label, entry.Comment)
+		}
+	}
+
+	last := entries[2]
+	if got := last.Started.Add(time.Duration(last.TimeSpentSeconds) * time.Second); !got.Equal(end) {
+		t.Errorf("last entry ends at %s, want %s", got, end)
+	}
+	if entries[0].IssueSummary != "First" || entries[1].IssueSummary != "" {
+		t.Errorf("unexpected summaries %q and %q", entries[0].IssueSummary, entries[1].IssueSummary)
+	}
+	if entries[1].TimeSpent != "45m" {
+		t.Errorf("TimeSpent = %q, want 45m", entries[1].TimeSpent)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/standup"
+	"tasklog/internal/storage"
+	"tasklog/internal/ui"
+)
+
+var standupCopy bool
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Draft standup notes from your worklogs",
+	Long: `Builds a "Yesterday / Today / Blockers" draft in markdown:
+
+- Yesterday: time logged on the previous workday (Friday on Mondays), with worklog comments
+- Today: your in-progress issues (jira.task_statuses)
+- Blockers: your issues in a blocked status (jira.blocked_statuses, default "Blocked")
+
+Examples:
+  tasklog standup          # Print the draft
+  tasklog standup --copy   # Also copy it to the clipboard` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runStandup,
+}
+
+func init() {
+	rootCmd.AddCommand(standupCmd)
+
+	standupCmd.Flags().BoolVarP(&standupCopy, "copy", "c", false, "Copy the draft to the clipboard")
+}
+
+func runStandup(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	previousDay := standup.PreviousWorkday(now)
+	entries, err := store.GetEntriesBetween(previousDay, previousDay.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+
+	jiraClient := newJiraClient(cfg)
+
+	inProgress, err := jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
+	if err != nil {
+		return err
+	}
+
+	// A missing blocked status only affects one section, so keep going without it
+	blocked, err := jiraClient.GetBlockedIssues(cfg.Jira.BlockedStatuses)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch blocked issues; check jira.blocked_statuses")
+	}
+
+	notes := standup.Build(now, entries, inProgress, blocked)
+	draft := notes.Markdown()
+	fmt.Print(draft)
+
+	if standupCopy {
+		if err := ui.CopyToClipboard(draft); err != nil {
+			return err
+		}
+		fmt.Println("\n📋 Copied to clipboard")
+	}
+
+	return nil
+}
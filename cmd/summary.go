@@ -5,9 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"tasklog/internal/jira"
 	"tasklog/internal/storage"
-	"tasklog/internal/tempo"
 )
 
 var summaryCmd = &cobra.Command{
@@ -29,13 +27,13 @@ func runSummary(cmd *cobra.Command, args []string) error {
 	}
 
 	// Tempo is required for summary
-	if !cfg.Tempo.Enabled || cfg.Tempo.APIToken == "" {
+	if !cfg.UsesTempo() {
 		return fmt.Errorf("tempo must be enabled and configured to use summary command")
 	}
 
 	// Initialize clients
-	jiraClient := jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
-	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
+	jiraClient := newJiraClient(cfg)
+	tempoClient := newTempoClient(cfg)
 
 	// Initialize storage
 	store, err := storage.NewStorage(cfg.Database.Path)
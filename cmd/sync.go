@@ -6,7 +6,6 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"tasklog/internal/jira"
 	"tasklog/internal/storage"
 )
 
@@ -29,7 +28,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize clients
-	jiraClient := jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
+	jiraClient := newJiraClient(cfg)
 
 	// Initialize storage
 	store, err := storage.NewStorage(cfg.Database.Path)
@@ -57,10 +56,23 @@ func runSync(cmd *cobra.Command, args []string) error {
 	for i, entry := range entries {
 		fmt.Printf("[%d/%d] Syncing %s - %s\n", i+1, len(entries), entry.IssueKey, entry.TimeSpent)
 
+		// Entries breaking the policy stay unsynced until the policy allows them
+		if !entry.SyncedToJira {
+			w, err := entryPolicyWorklog(cfg, jiraClient, &entry)
+			if err == nil {
+				err = enforcePolicy(cfg, w)
+			}
+			if err != nil {
+				fmt.Printf("  ✗ Not synced: %v\n", err)
+				failureCount++
+				continue
+			}
+		}
+
 		// Sync to Jira if not synced
 		if !entry.SyncedToJira {
 			log.Debug().Int64("id", entry.ID).Msg("Syncing to Jira")
-			worklog, err := jiraClient.AddWorklog(entry.IssueKey, entry.TimeSpentSeconds, entry.Started, entry.Comment)
+			worklog, err := jiraClient.AddWorklog(entry.IssueKey, entry.TimeSpentSeconds, entry.Started, entry.Comment, entryEstimate(cfg, &entry))
 			if err != nil {
 				log.Error().Err(err).Int64("id", entry.ID).Msg("Failed to sync to Jira")
 				fmt.Printf("  ✗ Failed to sync to Jira: %v\n", err)
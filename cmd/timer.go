@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/checks"
+	"tasklog/internal/config"
+	"tasklog/internal/idle"
+	"tasklog/internal/jira"
+	"tasklog/internal/policy"
+	"tasklog/internal/slack"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
+)
+
+// maxSlackStatusLength is the longest status text Slack accepts
+const maxSlackStatusLength = 100
+
+var (
+	stopLabel   string
+	stopDiscard bool
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start [task-key]",
+	Short: "Start a timer for a task",
+	Long: `Starts a timer for a Jira task. Run 'tasklog stop' to log the elapsed time.
+
+If slack.task_status.enabled is true, your Slack status is set to
+"Working on PROJ-123 – summary" while the timer runs and restored on stop.
+
+Examples:
+  tasklog start             # Select from in-progress tasks
+  tasklog start PROJ-123    # Start timer for a specific task` + configHelp,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStart,
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running timer and log the time",
+	Long: `Stops the timer started with 'tasklog start', logs the elapsed time to Jira
+and restores your previous Slack status.
+
+If the machine was suspended, or 'tasklog watch' recorded idle time, you are asked
+whether to keep, discard or reassign each idle period to another task first.
+
+Examples:
+  tasklog stop                  # Log elapsed time (prompts for label and comment)
+  tasklog stop -l development   # Log with a specific label
+  tasklog stop --discard        # Stop without logging` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runStop,
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+
+	stopCmd.Flags().StringVarP(&stopLabel, "label", "l", "", "Work log label")
+	stopCmd.Flags().BoolVar(&stopDiscard, "discard", false, "Stop the timer without logging time")
+}
+
+func runStart(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	active, err := store.GetActiveTimer()
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		return fmt.Errorf("a timer is already running for %s since %s; run 'tasklog stop' first",
+			active.IssueKey, active.StartedAt.Format("15:04"))
+	}
+
+	key := ""
+	if len(args) > 0 {
+		key = args[0]
+	}
+
+	jiraClient := newJiraClient(cfg)
+	issue, err := selectIssue(jiraClient, cfg, key)
+	if err != nil {
+		return err
+	}
+
+	timer, err := startTimer(cfg, store, issue)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("⏱️  Timer started for %s - %s at %s\n", issue.Key, issue.Fields.Summary, timer.StartedAt.Format("15:04"))
+	fmt.Println("Run 'tasklog stop' to log the time.")
+	return nil
+}
+
+// startTimer starts a timer for the issue, switching the Slack status to the task if enabled
+func startTimer(cfg *config.Config, store *storage.Storage, issue *jira.Issue) (*storage.ActiveTimer, error) {
+	timer := &storage.ActiveTimer{
+		IssueKey:     issue.Key,
+		IssueSummary: issue.Fields.Summary,
+		StartedAt:    time.Now(),
+	}
+
+	// The uptime clock stops during suspend, so comparing it on stop reveals time spent asleep
+	if uptime, err := idle.Uptime(); err != nil {
+		log.Debug().Err(err).Msg("Uptime not available, suspend will not be detected")
+	} else {
+		timer.StartedUptime = uptime
+	}
+
+	// Remember the current Slack status so it can be restored on stop
+	if slackClient := taskStatusClient(cfg); slackClient != nil {
+		previous, err := slackClient.GetStatus()
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to read Slack status, it will be cleared on stop")
+		} else {
+			timer.PreviousStatusText = previous.Text
+			timer.PreviousStatusEmoji = previous.Emoji
+			timer.PreviousStatusExpiration = previous.Expiration
+		}
+
+		if err := slackClient.SetStatusUntil(taskStatusText(issue.Key, issue.Fields.Summary), cfg.Slack.TaskStatus.Emoji, 0); err != nil {
+			log.Error().Err(err).Msg("Failed to update Slack status")
+		} else {
+			fmt.Println("💬 Slack status updated")
+		}
+	}
+
+	if err := store.StartTimer(timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func runStop(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		fmt.Println("ℹ️  No timer is running. Start one with 'tasklog start'.")
+		return nil
+	}
+
+	now := time.Now()
+	timeSeconds := elapsedSeconds(timer.StartedAt, now)
+	fmt.Printf("⏱️  %s - %s: %s (since %s)\n", timer.IssueKey, timer.IssueSummary,
+		timeparse.Format(timeSeconds), timer.StartedAt.Format("15:04"))
+
+	if stopDiscard {
+		if err := store.ClearTimer(); err != nil {
+			return err
+		}
+		restoreTaskStatus(cfg, store, timer)
+		fmt.Println("🗑️  Timer discarded, no time logged")
+		return nil
+	}
+
+	return logTimerWithIdle(cfg, store, timer, stopLabel, now)
+}
+
+// logTimerWithIdle resolves idle time found while the timer ran, then logs what is left
+// together with any idle time reassigned to other tasks
+func logTimerWithIdle(cfg *config.Config, store *storage.Storage, timer *storage.ActiveTimer, label string, now time.Time) error {
+	jiraClient := newJiraClient(cfg)
+	plan, err := resolveIdleTime(cfg, store, jiraClient, timer, now)
+	if err != nil {
+		return err
+	}
+
+	var items []workItem
+	spans := timerSpans(timer.StartedAt, now, plan)
+	if len(spans) > 0 {
+		items = append(items, workItem{IssueKey: timer.IssueKey, IssueSummary: timer.IssueSummary, Spans: spans})
+	}
+	for _, reassigned := range plan.Reassigned {
+		items = append(items, workItem{
+			IssueKey:     reassigned.Issue.Key,
+			IssueSummary: reassigned.Issue.Fields.Summary,
+			Draft:        reassigned.Draft,
+			Spans:        []timeSpan{{Start: reassigned.Period.Start, Seconds: periodSeconds(reassigned.Period.Length)}},
+		})
+	}
+
+	if len(items) == 0 {
+		if err := store.ClearTimer(); err != nil {
+			return err
+		}
+		restoreTaskStatus(cfg, store, timer)
+		fmt.Printf("ℹ️  No time left to log for %s\n", timer.IssueKey)
+		return nil
+	}
+
+	return logTimer(cfg, store, jiraClient, timer, label, items)
+}
+
+// logTimer logs the timer's work after a final confirmation, then clears the timer and restores the Slack status.
+// The timer keeps running when nothing was logged, so stopping it again asks about the same time
+func logTimer(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, timer *storage.ActiveTimer, label string, items []workItem) error {
+	saved, err := saveWorkItems(store, jiraClient, cfg, items, label, true)
+	if !saved {
+		if err == nil {
+			fmt.Println("ℹ️  The timer keeps running; stop it again to log the time")
+		}
+		return err
+	}
+
+	if clearErr := store.ClearTimer(); clearErr != nil {
+		return clearErr
+	}
+	restoreTaskStatus(cfg, store, timer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	printTodaySummary(store, jiraClient, newTempoClient(cfg), cfg)
+	return nil
+}
+
+// timeSpan is a stretch of work with a known start
+type timeSpan struct {
+	Start       time.Time
+	Seconds     int
+	Approximate bool // Start is only roughly when the work began, so the span isn't checked for overlaps
+}
+
+// workItem is work on one issue, logged as one worklog per span
+type workItem struct {
+	IssueKey     string
+	IssueSummary string
+	Draft        *jira.IssueDraft // New issue created once the work is confirmed; IssueKey only names it until then
+	Spans        []timeSpan
+}
+
+// saveWork checks the worklog, prompts for the label (unless given) and a comment, then logs timeSeconds of work on the issue
+// if it meets the policy
+func saveWork(store *storage.Storage, jiraClient *jira.Client, cfg *config.Config, issueKey, issueSummary string, started time.Time, label string, timeSeconds int) error {
+	item := workItem{IssueKey: issueKey, IssueSummary: issueSummary, Spans: []timeSpan{{Start: started, Seconds: timeSeconds}}}
+	_, err := saveWorkItems(store, jiraClient, cfg, []workItem{item}, label, false)
+	return err
+}
+
+// saveWorkItems checks the worklogs, prompts for the label (unless given) and a comment per issue and enforces the policy.
+// Only then, and after confirmation if asked for, all worklogs are logged; it reports whether any were saved
+func saveWorkItems(store *storage.Storage, jiraClient *jira.Client, cfg *config.Config, items []workItem, label string, confirm bool) (bool, error) {
+	var worklogs []checks.Worklog
+	for _, item := range items {
+		for _, span := range item.Spans {
+			worklogs = append(worklogs, checks.Worklog{IssueKey: item.IssueKey, Started: span.Start, Seconds: span.Seconds, Exact: !span.Approximate})
+		}
+	}
+	if err := checkWorklogs(cfg, store, worklogs, time.Now()); err != nil {
+		return false, err
+	}
+
+	var entries []*storage.TimeEntry
+	itemEntries := make([][]*storage.TimeEntry, len(items))
+	for i, item := range items {
+		if len(items) > 1 {
+			fmt.Printf("\n%s - %s:\n", item.IssueKey, item.IssueSummary)
+		}
+
+		selectedLabel, err := selectWorkLabel(cfg, label)
+		if err != nil {
+			return false, err
+		}
+
+		comment, err := ui.PromptComment()
+		if err != nil {
+			return false, fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		for _, span := range item.Spans {
+			entry := &storage.TimeEntry{
+				IssueKey:         item.IssueKey,
+				IssueSummary:     item.IssueSummary,
+				TimeSpentSeconds: span.Seconds,
+				TimeSpent:        timeparse.Format(span.Seconds),
+				Label:            selectedLabel,
+				Comment:          comment,
+				Started:          span.Start,
+				StartExact:       !span.Approximate,
+			}
+
+			w, err := itemPolicyWorklog(cfg, jiraClient, item, entry)
+			if err != nil {
+				return false, err
+			}
+			if err := enforcePolicy(cfg, w); err != nil {
+				return false, err
+			}
+			entries = append(entries, entry)
+			itemEntries[i] = append(itemEntries[i], entry)
+		}
+	}
+
+	if confirm {
+		fmt.Println()
+		for _, entry := range entries {
+			end := entry.Started.Add(time.Duration(entry.TimeSpentSeconds) * time.Second)
+			fmt.Printf("  %-12s %s - %s  %-8s %s\n", entry.IssueKey, entry.Started.Format("15:04"), end.Format("15:04"), entry.TimeSpent, entry.Label)
+		}
+		fmt.Println()
+
+		message := "Log this time entry?"
+		if len(entries) > 1 {
+			message = fmt.Sprintf("Log these %d time entries?", len(entries))
+		}
+		confirmed, err := ui.Confirm(message)
+		if err != nil {
+			return false, fmt.Errorf("failed to confirm: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled.")
+			return false, nil
+		}
+	}
+
+	// New issues are only created now that the work is confirmed
+	for i, item := range items {
+		if item.Draft == nil {
+			continue
+		}
+		issue, err := createIssue(jiraClient, item.Draft)
+		if err != nil {
+			return false, err
+		}
+		for _, entry := range itemEntries[i] {
+			entry.IssueKey = issue.Key
+		}
+	}
+
+	for i, entry := range entries {
+		if err := saveTimeEntry(store, jiraClient, cfg, entry); err != nil {
+			return i > 0, err
+		}
+	}
+	return true, nil
+}
+
+// itemPolicyWorklog describes an entry of a work item for the policy; a new issue's type is known from its draft
+func itemPolicyWorklog(cfg *config.Config, jiraClient *jira.Client, item workItem, entry *storage.TimeEntry) (policy.Worklog, error) {
+	if item.Draft == nil {
+		return entryPolicyWorklog(cfg, jiraClient, entry)
+	}
+	return policy.Worklog{
+		IssueKey:  entry.IssueKey,
+		Project:   item.Draft.ProjectKey,
+		IssueType: item.Draft.IssueType,
+		Label:     entry.Label,
+		Comment:   entry.Comment,
+		Seconds:   entry.TimeSpentSeconds,
+	}, nil
+}
+
+// elapsedSeconds returns the time between start and end rounded to whole minutes, at least one minute
+func elapsedSeconds(start, end time.Time) int {
+	minutes := int(end.Sub(start).Round(time.Minute).Minutes())
+	if minutes < 1 {
+		minutes = 1
+	}
+	return minutes * 60
+}
+
+// taskStatusClient returns a Slack client when task status mirroring is enabled and Slack is configured
+func taskStatusClient(cfg *config.Config) *slack.Client {
+	if !cfg.Slack.TaskStatus.Enabled || cfg.Slack.UserToken == "" {
+		return nil
+	}
+	return slack.NewClient(cfg.Slack.UserToken, cfg.Slack.ChannelID)
+}
+
+// taskStatusText renders the "Working on" status, truncated to Slack's limit
+func taskStatusText(issueKey, summary string) string {
+	text := fmt.Sprintf("Working on %s – %s", issueKey, summary)
+	if runes := []rune(text); len(runes) > maxSlackStatusLength {
+		text = string(runes[:maxSlackStatusLength-1]) + "…"
+	}
+	return text
+}
+
+// setLoggedTaskStatus shows the logged task in the Slack status for the configured duration
+// Skipped while a timer or break owns the status
+func setLoggedTaskStatus(cfg *config.Config, store *storage.Storage, issue *jira.Issue) {
+	slackClient := taskStatusClient(cfg)
+	if slackClient == nil {
+		return
+	}
+
+	if timer, err := store.GetActiveTimer(); err != nil || timer != nil {
+		log.Debug().Msg("Timer running, leaving Slack status unchanged")
+		return
+	}
+	if active, err := store.GetActiveBreak(time.Now()); err != nil || active != nil {
+		log.Debug().Msg("Break in progress, leaving Slack status unchanged")
+		return
+	}
+
+	text := taskStatusText(issue.Key, issue.Fields.Summary)
+	if err := slackClient.SetStatus(text, cfg.Slack.TaskStatus.Emoji, cfg.Slack.TaskStatus.Duration); err != nil {
+		log.Error().Err(err).Msg("Failed to update Slack status")
+		return
+	}
+	fmt.Printf("💬 Slack status set for %d minutes\n", cfg.Slack.TaskStatus.Duration)
+}
+
+// restoreTaskStatus puts back the Slack status saved when the timer started
+// Skipped while a break owns the status
+func restoreTaskStatus(cfg *config.Config, store *storage.Storage, timer *storage.ActiveTimer) {
+	slackClient := taskStatusClient(cfg)
+	if slackClient == nil {
+		return
+	}
+
+	if active, err := store.GetActiveBreak(time.Now()); err != nil || active != nil {
+		log.Debug().Msg("Break in progress, leaving Slack status unchanged")
+		return
+	}
+
+	previous := &slack.Status{
+		Text:       timer.PreviousStatusText,
+		Emoji:      timer.PreviousStatusEmoji,
+		Expiration: timer.PreviousStatusExpiration,
+	}
+	if err := slackClient.RestoreStatus(previous); err != nil {
+		log.Error().Err(err).Msg("Failed to restore Slack status")
+		return
+	}
+	fmt.Println("💬 Slack status restored")
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+func TestElapsedSeconds(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		end      time.Time
+		expected int
+	}{
+		{name: "rounds to nearest minute", end: start.Add(90*time.Minute + 40*time.Second), expected: 91 * 60},
+		{name: "rounds down", end: start.Add(90*time.Minute + 10*time.Second), expected: 90 * 60},
+		{name: "at least one minute", end: start.Add(5 * time.Second), expected: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := elapsedSeconds(start, tt.end); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTaskStatusText(t *testing.T) {
+	if got := taskStatusText("PROJ-123", "Fix login"); got != "Working on PROJ-123 – Fix login" {
+		t.Errorf("unexpected status text: %q", got)
+	}
+
+	long := taskStatusText("PROJ-123", strings.Repeat("a", 200))
+	if utf8.RuneCountInString(long) != maxSlackStatusLength {
+		t.Errorf("expected status truncated to %d characters, got %d", maxSlackStatusLength, utf8.RuneCountInString(long))
+	}
+	if !strings.HasSuffix(long, "…") {
+		t.Errorf("expected truncated status to end with an ellipsis, got %q", long)
+	}
+}
+
+func TestTimerIdlePeriods(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	now := start.Add(4 * time.Hour)
+	timer := &storage.ActiveTimer{IssueKey: "PROJ-1", StartedAt: start, StartedUptime: 10 * time.Hour}
+
+	segments := []storage.IdleSegment{
+		// Started before the timer, clamped to its start
+		{StartedAt: start.Add(-10 * time.Minute), EndedAt: start.Add(20 * time.Minute), Reason: storage.IdleReasonIdle},
+		{StartedAt: start.Add(time.Hour), EndedAt: start.Add(90 * time.Minute), Reason: storage.IdleReasonSuspend},
+	}
+
+	// Uptime advanced 2h45m over 4h: 1h15m suspended, 30m of which the watcher recorded
+	periods := timerIdlePeriods(segments, timer, now, timer.StartedUptime+165*time.Minute)
+	if len(periods) != 3 {
+		t.Fatalf("expected 3 periods, got %+v", periods)
+	}
+
+	expected := []string{
+		"09:00 - 09:20 idle (20m)",
+		"10:00 - 10:30 suspended (30m)",
+		"suspended (45m)",
+	}
+	for i, want := range expected {
+		if got := periods[i].Describe(); got != want {
+			t.Errorf("period %d: expected %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestTimerIdlePeriods_NoSuspend(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	timer := &storage.ActiveTimer{IssueKey: "PROJ-1", StartedAt: start, StartedUptime: 10 * time.Hour}
+
+	if periods := timerIdlePeriods(nil, timer, start.Add(time.Hour), timer.StartedUptime+time.Hour); len(periods) != 0 {
+		t.Errorf("expected no idle periods, got %+v", periods)
+	}
+}
+
+func TestTimerSpans(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	tests := []struct {
+		name     string
+		plan     idlePlan
+		expected []string
+	}{
+		{
+			name:     "nothing removed",
+			expected: []string{"09:00 4h"},
+		},
+		{
+			name: "split around removed periods",
+			plan: idlePlan{Removed: []idlePeriod{
+				{Start: start.Add(2 * time.Hour), Length: 30 * time.Minute},
+				{Start: start.Add(time.Hour), Length: 15 * time.Minute},
+			}},
+			expected: []string{"09:00 1h", "10:15 45m", "11:30 1h 30m"},
+		},
+		{
+			name: "removed at the start and end",
+			plan: idlePlan{Removed: []idlePeriod{
+				{Start: start, Length: 20 * time.Minute},
+				{Start: end.Add(-10 * time.Minute), Length: 10 * time.Minute},
+			}},
+			expected: []string{"09:20 3h 30m"},
+		},
+		{
+			name: "unplaced time comes off the end",
+			plan: idlePlan{
+				Removed:  []idlePeriod{{Start: start.Add(3 * time.Hour), Length: 30 * time.Minute}},
+				Unplaced: 45 * time.Minute,
+			},
+			expected: []string{"09:00 2h 45m"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, span := range timerSpans(start, end, tt.plan) {
+				got = append(got, fmt.Sprintf("%s %s", span.Start.Format("15:04"), timeparse.Format(span.Seconds)))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestItemPolicyWorklog_NewIssue(t *testing.T) {
+	cfg := &config.Config{Policy: config.PolicyConfig{
+		LabelProjects: []config.LabelProject{{Label: "billable", Projects: []string{"ACME"}}},
+	}}
+	draft := &jira.IssueDraft{ProjectKey: "ACME", IssueType: "Task", Summary: "Outage"}
+	item := workItem{IssueKey: draftKey(draft), Draft: draft}
+	entry := &storage.TimeEntry{IssueKey: item.IssueKey, Label: "billable", TimeSpentSeconds: 3600}
+
+	// The draft is never looked up in Jira, so no client is needed
+	w, err := itemPolicyWorklog(cfg, nil, item, entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Project != "ACME" {
+		t.Errorf("expected the draft's project, got %q", w.Project)
+	}
+	if err := enforcePolicy(cfg, w); err != nil {
+		t.Errorf("expected a new ACME issue to allow the billable label, got %v", err)
+	}
+
+	draft.ProjectKey = "PROJ"
+	w, _ = itemPolicyWorklog(cfg, nil, item, entry)
+	if err := enforcePolicy(cfg, w); err == nil {
+		t.Error("expected a new PROJ issue to reject the billable label")
+	}
+}
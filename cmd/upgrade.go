@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -159,8 +160,13 @@ func runUpgrade(cmd *cobra.Command, args []string) error {
 }
 
 func confirmAction(prompt string) bool {
+	return confirmActionOn(os.Stdout, prompt)
+}
+
+// confirmActionOn is confirmAction with the question written to out
+func confirmActionOn(out io.Writer, prompt string) bool {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%s (y/N): ", prompt)
+	fmt.Fprintf(out, "%s (y/N): ", prompt)
 	response, err := reader.ReadString('\n')
 	if err != nil {
 		return false
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	str2duration "github.com/xhit/go-str2duration/v2"
+
+	"tasklog/internal/config"
+	"tasklog/internal/idle"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
+)
+
+// watchTickInterval is how often 'tasklog watch' samples idle time
+const watchTickInterval = 30 * time.Second
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Record idle time and suspend while a timer runs",
+	Long: `Watches timers started with 'tasklog start' or 'tasklog focus' and records when you
+are idle (no keyboard or mouse input for timer.idle_threshold, default 5m) or the
+machine is asleep. 'tasklog stop' then asks whether to keep, discard or reassign
+each idle segment before logging the time.
+
+Idle time is read from the GNOME idle monitor on Wayland, xprintidle on X11 or IOKit
+on macOS. Set timer.idle_command to a command printing idle milliseconds to use
+another source. Suspend is detected even without 'tasklog watch'.
+
+Keeps running across timers until stopped with Ctrl+C, so it can run in the background:
+  nohup tasklog watch &` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
+
+// timerWatch tracks idle time for one timer
+type timerWatch struct {
+	startedAt time.Time
+	tracker   *idle.Tracker
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	threshold, err := str2duration.ParseDuration(cfg.Timer.IdleThreshold)
+	if err != nil {
+		return fmt.Errorf("invalid timer.idle_threshold: %w", err)
+	}
+
+	detector, err := idle.Detect(cfg.Timer.IdleCommand)
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		fmt.Println("   Only suspend will be recorded.")
+	} else {
+		fmt.Printf("👀 Watching timers for idle time over %s using %s\n", cfg.Timer.IdleThreshold, detector.Name())
+	}
+	fmt.Println("Press Ctrl+C to stop.")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(watchTickInterval)
+	defer ticker.Stop()
+
+	var watch *timerWatch
+	for {
+		watch = watchTick(cfg, detector, threshold, watch)
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n👋 Stopped watching")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchTick samples idle time once and stores new or changed segments of the running timer
+// It returns the watch state for the next tick (nil while no timer runs)
+func watchTick(cfg *config.Config, detector idle.Detector, threshold time.Duration, watch *timerWatch) *timerWatch {
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to open database")
+		return watch
+	}
+	defer store.Close()
+
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check for a running timer")
+		return watch
+	}
+	if timer == nil {
+		return nil
+	}
+
+	if watch == nil || !watch.startedAt.Equal(timer.StartedAt) {
+		fmt.Printf("⏱️  %s Watching %s - %s\n", time.Now().Format("15:04"), timer.IssueKey, timer.IssueSummary)
+		watch = &timerWatch{startedAt: timer.StartedAt, tracker: idle.NewTracker(threshold)}
+	}
+
+	var idleTime time.Duration
+	if detector != nil {
+		if idleTime, err = detector.IdleTime(); err != nil {
+			log.Warn().Err(err).Str("detector", detector.Name()).Msg("Failed to read idle time")
+			idleTime = 0
+		}
+	}
+
+	for _, seg := range watch.tracker.Observe(time.Now(), idleTime) {
+		if seg.StartedAt.Before(timer.StartedAt) {
+			seg.StartedAt = timer.StartedAt
+		}
+
+		if seg.ID != 0 {
+			if err := store.UpdateIdleSegmentEnd(seg.ID, seg.EndedAt); err != nil {
+				log.Warn().Err(err).Msg("Failed to update idle segment")
+			}
+			continue
+		}
+
+		if err := store.AddIdleSegment(seg); err != nil {
+			log.Warn().Err(err).Msg("Failed to record idle segment")
+			continue
+		}
+		if seg.Reason == storage.IdleReasonSuspend {
+			fmt.Printf("😴 Suspended from %s to %s\n", seg.StartedAt.Format("15:04"), seg.EndedAt.Format("15:04"))
+		} else {
+			fmt.Printf("💤 Idle since %s\n", seg.StartedAt.Format("15:04"))
+		}
+	}
+
+	return watch
+}
+
+// idlePeriod is time while a timer ran that may not have been spent working
+type idlePeriod struct {
+	Start  time.Time // Zero when only the length is known (suspend detected without 'tasklog watch')
+	Length time.Duration
+	Reason string // storage.IdleReasonIdle or storage.IdleReasonSuspend
+}
+
+// Describe renders the period for prompts, e.g. "10:42 - 11:15 idle (33m)"
+func (p idlePeriod) Describe() string {
+	what := "idle"
+	if p.Reason == storage.IdleReasonSuspend {
+		what = "suspended"
+	}
+	length := timeparse.Format(periodSeconds(p.Length))
+	if p.Start.IsZero() {
+		return fmt.Sprintf("%s (%s)", what, length)
+	}
+	return fmt.Sprintf("%s - %s %s (%s)", p.Start.Format("15:04"), p.Start.Add(p.Length).Format("15:04"), what, length)
+}
+
+// timerIdlePeriods returns the idle periods of a timer: segments recorded by 'tasklog watch' within the
+// timer's run, plus suspend measured from the uptime clock that the watcher didn't record
+func timerIdlePeriods(segments []storage.IdleSegment, timer *storage.ActiveTimer, now time.Time, uptime time.Duration) []idlePeriod {
+	var periods []idlePeriod
+	var recordedSuspend time.Duration
+
+	for _, seg := range segments {
+		start, end := seg.StartedAt, seg.EndedAt
+		if start.Before(timer.StartedAt) {
+			start = timer.StartedAt
+		}
+		if end.After(now) {
+			end = now
+		}
+		if !end.After(start) {
+			continue
+		}
+		if seg.Reason == storage.IdleReasonSuspend {
+			recordedSuspend += end.Sub(start)
+		}
+		periods = append(periods, idlePeriod{Start: start, Length: end.Sub(start), Reason: seg.Reason})
+	}
+
+	suspended := idle.SuspendedBetween(timer.StartedAt, timer.StartedUptime, now, uptime)
+	if missing := suspended - recordedSuspend; missing >= idle.MinSuspend {
+		periods = append(periods, idlePeriod{Length: missing, Reason: storage.IdleReasonSuspend})
+	}
+
+	return periods
+}
+
+// periodSeconds rounds an idle period to whole minutes
+func periodSeconds(d time.Duration) int {
+	return int(d.Round(time.Minute).Seconds())
+}
+
+// idleReassignment is an idle period to log to another task
+type idleReassignment struct {
+	Issue  *jira.Issue
+	Draft  *jira.IssueDraft // New task to create once the time is confirmed; Issue stands in for it until then
+	Period idlePeriod
+}
+
+// idlePlan records what should happen to the idle periods of a timer; nothing is logged until the timer is
+type idlePlan struct {
+	Removed    []idlePeriod  // Discarded or reassigned periods, cut out of the timer's span
+	Unplaced   time.Duration // Discarded time without a known start, taken off the end
+	Reassigned []idleReassignment
+}
+
+// resolveIdleTime asks whether to keep, discard or reassign each idle period of the timer.
+// Only periods with a known start can be reassigned, so they can be cut out of the timer's span
+func resolveIdleTime(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, timer *storage.ActiveTimer, now time.Time) (idlePlan, error) {
+	var plan idlePlan
+
+	segments, err := store.GetIdleSegments()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to read idle segments")
+	}
+	uptime, err := idle.Uptime()
+	if err != nil {
+		log.Debug().Err(err).Msg("Uptime not available, suspend not checked")
+	}
+
+	periods := timerIdlePeriods(segments, timer, now, uptime)
+	if len(periods) == 0 {
+		return plan, nil
+	}
+
+	if !ui.IsInteractive() {
+		fmt.Printf("⚠️  %d idle periods found; keeping them since input is not interactive\n", len(periods))
+		return plan, nil
+	}
+
+	fmt.Println("💤 Idle time found while the timer was running:")
+	for _, period := range periods {
+		fmt.Printf("   %s\n", period.Describe())
+	}
+
+	const keepOption, discardOption, reassignOption = "Keep it", "Discard it", "Reassign it to another task"
+	for _, period := range periods {
+		if periodSeconds(period.Length) == 0 {
+			continue
+		}
+
+		options := []string{keepOption, discardOption}
+		if !period.Start.IsZero() {
+			options = append(options, reassignOption)
+		}
+		choice, err := ui.Select(fmt.Sprintf("%s - what should happen to it?", period.Describe()), options)
+		if err != nil {
+			return plan, err
+		}
+
+		switch choice {
+		case discardOption:
+			if period.Start.IsZero() {
+				plan.Unplaced += period.Length
+			} else {
+				plan.Removed = append(plan.Removed, period)
+			}
+		case reassignOption:
+			issue, draft, err := selectIssueOrDraft(jiraClient, cfg, "")
+			if err != nil {
+				return plan, err
+			}
+			if draft != nil {
+				issue = draftIssue(draft)
+			}
+			plan.Removed = append(plan.Removed, period)
+			plan.Reassigned = append(plan.Reassigned, idleReassignment{Issue: issue, Draft: draft, Period: period})
+		}
+	}
+
+	return plan, nil
+}
+
+// timerSpans splits the timer's span from start to end around the removed periods and takes the
+// unplaced time off the end. Spans are rounded to whole minutes; ones shorter than a minute are dropped
+func timerSpans(start, end time.Time, plan idlePlan) []timeSpan {
+	if len(plan.Removed) == 0 && plan.Unplaced == 0 {
+		return []timeSpan{{Start: start, Seconds: elapsedSeconds(start, end)}}
+	}
+
+	removed := slices.Clone(plan.Removed)
+	slices.SortFunc(removed, func(a, b idlePeriod) int { return a.Start.Compare(b.Start) })
+
+	type span struct {
+		start  time.Time
+		length time.Duration
+	}
+	var spans []span
+	cursor := start
+	for _, period := range removed {
+		if period.Start.After(cursor) {
+			spans = append(spans, span{cursor, period.Start.Sub(cursor)})
+		}
+		if periodEnd := period.Start.Add(period.Length); periodEnd.After(cursor) {
+			cursor = periodEnd
+		}
+	}
+	if end.After(cursor) {
+		spans = append(spans, span{cursor, end.Sub(cursor)})
+	}
+
+	// Time without a known place comes off the latest spans
+	cut := plan.Unplaced
+	for i := len(spans) - 1; i >= 0 && cut > 0; i-- {
+		taken := min(cut, spans[i].length)
+		spans[i].length -= taken
+		cut -= taken
+	}
+
+	var result []timeSpan
+	for _, s := range spans {
+		if seconds := periodSeconds(s.length); seconds >= 60 {
+			result = append(result, timeSpan{Start: s.start, Seconds: seconds})
+		}
+	}
+	return result
+}
@@ -0,0 +1,165 @@
+package checks
+
+import (
+	"fmt"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/timeparse"
+
+	str2duration "github.com/xhit/go-str2duration/v2"
+)
+
+// Level says how a failed check reacts
+type Level string
+
+// Check levels
+const (
+	LevelOff   Level = "off"
+	LevelWarn  Level = "warn"
+	LevelBlock Level = "block"
+)
+
+// Tolerance absorbs rounding: overlaps and future ends shorter than this are ignored
+const Tolerance = 5 * time.Minute
+
+// Policy holds the level of each check
+type Policy struct {
+	Overlap    Level
+	DailyTotal Level
+	DailyMax   time.Duration // Most work per day before DailyTotal triggers
+	Weekend    Level
+	Future     Level
+}
+
+// NewPolicy builds a policy from the checks config
+func NewPolicy(cfg config.ChecksConfig) Policy {
+	policy := Policy{
+		Overlap:    Level(cfg.Overlap),
+		DailyTotal: Level(cfg.DailyTotal),
+		Weekend:    Level(cfg.Weekend),
+		Future:     Level(cfg.Future),
+	}
+	if dailyMax, err := str2duration.ParseDuration(cfg.DailyMax); err == nil {
+		policy.DailyMax = dailyMax
+	}
+	return policy
+}
+
+// Worklog is a span of logged work
+type Worklog struct {
+	IssueKey string
+	Started  time.Time
+	Seconds  int
+	Exact    bool // Started is the real start (ranges, timers) rather than a guess; only exact worklogs are checked for overlaps
+}
+
+// End returns when the work ended
+func (w Worklog) End() time.Time {
+	return w.Started.Add(time.Duration(w.Seconds) * time.Second)
+}
+
+// span renders the worklog like "PROJ-1 (09:00-10:30)"
+func (w Worklog) span() string {
+	return fmt.Sprintf("%s (%s-%s)", w.IssueKey, w.Started.Format("15:04"), w.End().Format("15:04"))
+}
+
+// Problem is a failed check
+type Problem struct {
+	Level   Level
+	Message string
+}
+
+// Blocked reports whether any problem blocks saving
+func Blocked(problems []Problem) bool {
+	for _, problem := range problems {
+		if problem.Level == LevelBlock {
+			return true
+		}
+	}
+	return false
+}
+
+// Check runs the policy on new worklogs against the existing ones of the same days
+// New worklogs are also checked against each other, in order
+func (p Policy) Check(entries, existing []Worklog, now time.Time) []Problem {
+	var problems []Problem
+	add := func(level Level, format string, args ...any) {
+		if level == LevelWarn || level == LevelBlock {
+			problems = append(problems, Problem{Level: level, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	logged := append([]Worklog(nil), existing...)
+	totals := make(map[string]int)
+	for _, w := range existing {
+		totals[dayKey(w.Started, now)] += w.Seconds
+	}
+
+	var days []time.Time
+	for _, entry := range entries {
+		switch {
+		case entry.Started.After(now.Add(Tolerance)):
+			add(p.Future, "%s starts in the future (%s)", entry.IssueKey, entry.Started.Format("Mon Jan 2 15:04"))
+		case entry.Exact && entry.End().After(now.Add(Tolerance)):
+			add(p.Future, "%s ends in the future (%s)", entry.IssueKey, entry.End().Format("15:04"))
+		}
+
+		if weekday := entry.Started.In(now.Location()).Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			add(p.Weekend, "%s is logged on a %s", entry.IssueKey, weekday)
+		}
+
+		if entry.Exact {
+			for _, other := range logged {
+				if other.Exact && overlap(entry, other) > Tolerance {
+					add(p.Overlap, "%s overlaps %s", entry.span(), other.span())
+				}
+			}
+		}
+		logged = append(logged, entry)
+
+		key := dayKey(entry.Started, now)
+		if !containsDay(days, key, now) {
+			days = append(days, entry.Started)
+		}
+		totals[key] += entry.Seconds
+	}
+
+	if p.DailyMax > 0 {
+		for _, day := range days {
+			if total := totals[dayKey(day, now)]; total > int(p.DailyMax.Seconds()) {
+				add(p.DailyTotal, "%s would have %s logged, over the %s daily maximum",
+					day.In(now.Location()).Format("Monday, January 2"), timeparse.Format(total), timeparse.Format(int(p.DailyMax.Seconds())))
+			}
+		}
+	}
+
+	return problems
+}
+
+// overlap returns how long two worklogs overlap
+func overlap(a, b Worklog) time.Duration {
+	start, end := a.Started, a.End()
+	if b.Started.After(start) {
+		start = b.Started
+	}
+	if b.End().Before(end) {
+		end = b.End()
+	}
+	return end.Sub(start)
+}
+
+// dayKey identifies the local day of t
+func dayKey(t, now time.Time) string {
+	return t.In(now.Location()).Format("2006-01-02")
+}
+
+// containsDay reports whether days already has the day with the given key
+func containsDay(days []time.Time, key string, now time.Time) bool {
+	for _, day := range days {
+		if dayKey(day, now) == key {
+			return true
+		}
+	}
+	return false
+}
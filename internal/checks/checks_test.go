@@ -0,0 +1,127 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+)
+
+func TestCheck(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 3, 4, 16, 0, 0, 0, time.UTC)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+	policy := Policy{Overlap: LevelWarn, DailyTotal: LevelWarn, DailyMax: 10 * time.Hour, Weekend: LevelWarn, Future: LevelBlock}
+	morning := Worklog{IssueKey: "PROJ-1", Started: at(4, 9, 0), Seconds: 2 * 3600, Exact: true}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		entries  []Worklog
+		existing []Worklog
+		expected []string // Expected message fragments, in order
+		blocked  bool
+	}{
+		{
+			name:     "no problems",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(4, 11, 0), Seconds: 3600, Exact: true}},
+			existing: []Worklog{morning},
+		},
+		{
+			name:     "overlap",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(4, 10, 0), Seconds: 3600, Exact: true}},
+			existing: []Worklog{morning},
+			expected: []string{"PROJ-2 (10:00-11:00) overlaps PROJ-1 (09:00-11:00)"},
+		},
+		{
+			name:     "overlap within tolerance",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(4, 10, 57), Seconds: 3600, Exact: true}},
+			existing: []Worklog{morning},
+		},
+		{
+			name:     "guessed start is not checked for overlap",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(4, 10, 0), Seconds: 3600}},
+			existing: []Worklog{morning},
+		},
+		{
+			name:     "guessed existing start is not checked for overlap",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(4, 10, 0), Seconds: 3600, Exact: true}},
+			existing: []Worklog{{IssueKey: "PROJ-1", Started: at(4, 9, 0), Seconds: 2 * 3600}},
+		},
+		{
+			name:   "new worklogs overlap each other",
+			policy: policy,
+			entries: []Worklog{
+				{IssueKey: "PROJ-2", Started: at(4, 12, 0), Seconds: 3600, Exact: true},
+				{IssueKey: "PROJ-3", Started: at(4, 12, 30), Seconds: 3600, Exact: true},
+			},
+			expected: []string{"PROJ-3 (12:30-13:30) overlaps PROJ-2 (12:00-13:00)"},
+		},
+		{
+			name:     "daily maximum",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(4, 6, 0), Seconds: 9 * 3600}},
+			existing: []Worklog{morning},
+			expected: []string{"Wednesday, March 4 would have 11h logged, over the 10h daily maximum"},
+		},
+		{
+			name:     "weekend",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(1, 10, 0), Seconds: 3600}},
+			expected: []string{"PROJ-2 is logged on a Sunday"},
+		},
+		{
+			name:     "future start blocks",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(5, 10, 0), Seconds: 3600}},
+			expected: []string{"PROJ-2 starts in the future"},
+			blocked:  true,
+		},
+		{
+			name:     "future end blocks",
+			policy:   policy,
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(4, 15, 0), Seconds: 2 * 3600, Exact: true}},
+			expected: []string{"PROJ-2 ends in the future (17:00)"},
+			blocked:  true,
+		},
+		{
+			name:     "checks turned off",
+			policy:   Policy{Overlap: LevelOff, DailyTotal: LevelOff, DailyMax: time.Hour, Weekend: LevelOff, Future: LevelOff},
+			entries:  []Worklog{{IssueKey: "PROJ-2", Started: at(7, 10, 0), Seconds: 3 * 3600, Exact: true}},
+			existing: []Worklog{{IssueKey: "PROJ-1", Started: at(7, 11, 0), Seconds: 3600}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := tt.policy.Check(tt.entries, tt.existing, now)
+			if len(problems) != len(tt.expected) {
+				t.Fatalf("got %d problems %+v, want %d", len(problems), problems, len(tt.expected))
+			}
+			for i, fragment := range tt.expected {
+				if !strings.Contains(problems[i].Message, fragment) {
+					t.Errorf("problem %d = %q, want it to contain %q", i, problems[i].Message, fragment)
+				}
+			}
+			if got := Blocked(problems); got != tt.blocked {
+				t.Errorf("Blocked() = %v, want %v", got, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	policy := NewPolicy(config.ChecksConfig{Overlap: "block", DailyTotal: "warn", DailyMax: "9h30m", Weekend: "off", Future: "block"})
+	expected := Policy{Overlap: LevelBlock, DailyTotal: LevelWarn, DailyMax: 9*time.Hour + 30*time.Minute, Weekend: LevelOff, Future: LevelBlock}
+	if policy != expected {
+		t.Errorf("NewPolicy() = %+v, want %+v", policy, expected)
+	}
+}
@@ -18,23 +18,69 @@ func TestCompareWithExample(t *testing.T) {
 			userConfig: `version: 1
 jira:
   url: "https://example.com"
+  deployment: "cloud"
   username: "user@example.com"
   api_token: "token"
   project_key: "PROJ"
   task_statuses:
     - "In Progress"
+  blocked_statuses:
+    - "Blocked"
   shortcuts: []
 tempo:
   enabled: false
   api_token: ""
+oauth:
+  client_id: ""
+  client_secret: ""
+  tempo_client_id: ""
+  tempo_client_secret: ""
+  redirect_port: 8765
 labels:
   allowed_labels: []
+time:
+  round_to: 5
+  round_mode: "nearest"
+  workday: "8h"
+checks:
+  overlap: "warn"
+  daily_total: "warn"
+  daily_max: "12h"
+  weekend: "warn"
+  future: "block"
+  tempo: false
+policy:
+  require_comment: false
+  require_comment_types: []
+  min_comment_length: 0
+  max_entry: ""
+  label_projects: []
 database:
   path: ""
 slack:
   user_token: "token"
   channel_id: "C123"
+  daily_thread: false
   breaks: []
+  task_status:
+    enabled: false
+    emoji: ":computer:"
+    duration: 30
+notifier:
+  type: "slack"
+  webhook_url: ""
+timer:
+  idle_threshold: "5m"
+  idle_command: ""
+pomodoro:
+  work: 25
+  short_break: 5
+  long_break: 15
+  long_break_every: 4
+  break: ""
+report:
+  daily_target: "8h"
+  channel_id: ""
 update:
   disabled: false
   check_interval: "24h"
@@ -47,18 +93,21 @@ update:
 			userConfig: `version: 1
 jira:
   url: "https://example.com"
+  deployment: "cloud"
   username: "user@example.com"
   api_token: "token"
   project_key: "PROJ"
   task_statuses:
     - "In Progress"
+  blocked_statuses:
+    - "Blocked"
   shortcuts: []
 tempo:
   enabled: false
   api_token: ""
 `,
 			expectUpToDate:    false,
-			expectMissingKeys: []string{"labels", "database", "slack", "update"},
+			expectMissingKeys: []string{"oauth", "labels", "time", "checks", "policy", "database", "slack", "notifier", "timer", "pomodoro", "report", "update"},
 		},
 		{
 			name: "missing nested fields",
@@ -71,42 +120,126 @@ jira:
 tempo:
   enabled: false
   api_token: ""
+oauth:
+  client_id: ""
+  client_secret: ""
+  tempo_client_id: ""
+  tempo_client_secret: ""
+  redirect_port: 8765
 labels:
   allowed_labels: []
+time:
+  round_to: 5
+  round_mode: "nearest"
+  workday: "8h"
+checks:
+  overlap: "warn"
+  daily_total: "warn"
+  daily_max: "12h"
+  weekend: "warn"
+  future: "block"
+  tempo: false
+policy:
+  require_comment: false
+  require_comment_types: []
+  min_comment_length: 0
+  max_entry: ""
+  label_projects: []
 database:
   path: ""
 slack:
   user_token: "token"
   channel_id: "C123"
+notifier:
+  type: "slack"
+  webhook_url: ""
+timer:
+  idle_threshold: "5m"
+  idle_command: ""
+pomodoro:
+  work: 25
+  short_break: 5
+  long_break: 15
+  long_break_every: 4
+  break: ""
+report:
+  daily_target: "8h"
+  channel_id: ""
 update:
   disabled: false
   check_interval: "24h"
 `,
 			expectUpToDate:    false,
-			expectMissingKeys: []string{"jira.task_statuses", "jira.shortcuts", "slack.breaks", "update.channel"},
+			expectMissingKeys: []string{"jira.deployment", "jira.task_statuses", "jira.blocked_statuses", "jira.shortcuts", "slack.breaks", "slack.task_status", "slack.daily_thread", "update.channel"},
 		},
 		{
 			name: "extra deprecated fields",
 			userConfig: `version: 1
 jira:
   url: "https://example.com"
+  deployment: "cloud"
   username: "user@example.com"
   api_token: "token"
   project_key: "PROJ"
   task_statuses:
     - "In Progress"
+  blocked_statuses:
+    - "Blocked"
   shortcuts: []
 tempo:
   enabled: false
   api_token: ""
+oauth:
+  client_id: ""
+  client_secret: ""
+  tempo_client_id: ""
+  tempo_client_secret: ""
+  redirect_port: 8765
 labels:
   allowed_labels: []
+time:
+  round_to: 5
+  round_mode: "nearest"
+  workday: "8h"
+checks:
+  overlap: "warn"
+  daily_total: "warn"
+  daily_max: "12h"
+  weekend: "warn"
+  future: "block"
+  tempo: false
+policy:
+  require_comment: false
+  require_comment_types: []
+  min_comment_length: 0
+  max_entry: ""
+  label_projects: []
 database:
   path: ""
 slack:
   user_token: "token"
   channel_id: "C123"
+  daily_thread: false
   breaks: []
+  task_status:
+    enabled: false
+    emoji: ":computer:"
+    duration: 30
+notifier:
+  type: "slack"
+  webhook_url: ""
+timer:
+  idle_threshold: "5m"
+  idle_command: ""
+pomodoro:
+  work: 25
+  short_break: 5
+  long_break: 15
+  long_break_every: 4
+  break: ""
+report:
+  daily_target: "8h"
+  channel_id: ""
 update:
   disabled: false
   check_interval: "24h"
@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	"tasklog/internal/timeparse"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog/log"
+	str2duration "github.com/xhit/go-str2duration/v2"
 	"gopkg.in/yaml.v3"
 )
 
@@ -20,26 +25,67 @@ type Config struct {
 	Version  int            `yaml:"version,omitempty"` // Schema version for migrations
 	Jira     JiraConfig     `yaml:"jira"`
 	Tempo    TempoConfig    `yaml:"tempo"`
+	OAuth    OAuthConfig    `yaml:"oauth"` // OAuth 2.0 apps for 'tasklog login' instead of API tokens (optional)
 	Labels   LabelsConfig   `yaml:"labels"`
+	Time     TimeConfig     `yaml:"time"`   // Time input rounding and workday length (optional)
+	Checks   ChecksConfig   `yaml:"checks"` // Sanity checks before saving worklogs (optional)
+	Policy   PolicyConfig   `yaml:"policy"` // Worklog rules enforced when logging and syncing (optional)
 	Database DatabaseConfig `yaml:"database"`
 	Slack    SlackConfig    `yaml:"slack"`
-	Update   UpdateConfig   `yaml:"update"` // Update checking configuration (optional)
+	Notifier NotifierConfig `yaml:"notifier"` // Where break notifications are sent (optional)
+	Timer    TimerConfig    `yaml:"timer"`    // Idle detection for running timers (optional)
+	Pomodoro PomodoroConfig `yaml:"pomodoro"` // Pomodoro interval lengths (optional)
+	Report   ReportConfig   `yaml:"report"`   // Worklog report configuration (optional)
+	Update   UpdateConfig   `yaml:"update"`   // Update checking configuration (optional)
 }
 
+// Jira deployment types
+const (
+	JiraCloud  = "cloud"  // Jira Cloud: email and API token, REST API v3
+	JiraServer = "server" // Jira Server or Data Center: personal access token, REST API v2
+)
+
 // JiraConfig contains Jira API configuration (all fields required)
 type JiraConfig struct {
-	URL          string          `yaml:"url" validate:"required,url"`        // Jira instance URL (required)
-	Username     string          `yaml:"username" validate:"required,email"` // Jira username/email (required)
-	APIToken     string          `yaml:"api_token" validate:"required"`      // Jira API token (required)
-	ProjectKey   string          `yaml:"project_key" validate:"required"`    // Project key to filter tasks (required)
-	TaskStatuses []string        `yaml:"task_statuses"`                      // Task statuses to include (optional, defaults to ["In Progress"])
-	Shortcuts    []ShortcutEntry `yaml:"shortcuts"`                          // Predefined shortcuts for quick time logging (optional)
+	URL             string          `yaml:"url" validate:"required,url"`                        // Jira instance URL (required)
+	Deployment      string          `yaml:"deployment" validate:"omitempty,oneof=cloud server"` // Jira deployment type (optional, defaults to "cloud")
+	Username        string          `yaml:"username"`                                           // Jira email on Cloud, username on Server (required without oauth)
+	APIToken        string          `yaml:"api_token"`                                          // Jira API token (Cloud) or personal access token (Server), or secret reference (required without oauth)
+	ProjectKey      string          `yaml:"project_key" validate:"required"`                    // Project key to filter tasks (required)
+	TaskStatuses    []string        `yaml:"task_statuses"`                                      // Task statuses to include (optional, defaults to ["In Progress"])
+	BlockedStatuses []string        `yaml:"blocked_statuses"`                                   // Statuses listed as blockers by 'tasklog standup' (optional, defaults to ["Blocked"])
+	Shortcuts       []ShortcutEntry `yaml:"shortcuts"`                                          // Predefined shortcuts for quick time logging (optional)
+}
+
+// IsServer reports whether Jira is self-hosted (Server or Data Center)
+func (j JiraConfig) IsServer() bool {
+	return j.Deployment == JiraServer
 }
 
 // TempoConfig contains Tempo API configuration (optional)
 type TempoConfig struct {
-	APIToken string `yaml:"api_token" validate:"required_if=Enabled true"` // Tempo API token (optional - only if logging separately to Tempo)
-	Enabled  bool   `yaml:"enabled"`                                       // Whether to log to Tempo separately (optional, default: false)
+	APIToken string `yaml:"api_token"` // Tempo API token (optional - only if logging separately to Tempo without oauth)
+	Enabled  bool   `yaml:"enabled"`   // Whether to log to Tempo separately (optional, default: false)
+}
+
+// OAuthConfig holds the OAuth 2.0 apps used by 'tasklog login' (optional)
+// Tokens from 'tasklog login' replace jira.api_token and tempo.api_token
+type OAuthConfig struct {
+	ClientID          string `yaml:"client_id"`           // Atlassian OAuth 2.0 (3LO) app client ID (optional)
+	ClientSecret      string `yaml:"client_secret"`       // Atlassian app secret or secret reference
+	TempoClientID     string `yaml:"tempo_client_id"`     // Tempo OAuth 2.0 app client ID (optional)
+	TempoClientSecret string `yaml:"tempo_client_secret"` // Tempo app secret or secret reference
+	RedirectPort      int    `yaml:"redirect_port"`       // Port of the registered http://localhost callback (default: 8765)
+}
+
+// Enabled reports whether Jira is accessed with tokens from 'tasklog login'
+func (o OAuthConfig) Enabled() bool {
+	return o.ClientID != ""
+}
+
+// TempoEnabled reports whether Tempo is accessed with tokens from 'tasklog login'
+func (o OAuthConfig) TempoEnabled() bool {
+	return o.TempoClientID != ""
 }
 
 // LabelsConfig contains label filtering configuration (optional)
@@ -49,10 +95,11 @@ type LabelsConfig struct {
 
 // ShortcutEntry represents a predefined shortcut for quick time logging (optional)
 type ShortcutEntry struct {
-	Name  string `yaml:"name"`  // Shortcut name (e.g., "daily")
-	Task  string `yaml:"task"`  // Jira task key (e.g., "PROJ-123")
-	Time  string `yaml:"time"`  // Optional: predefined time (e.g., "30m")
-	Label string `yaml:"label"` // Work log label
+	Name       string `yaml:"name"`                 // Shortcut name (e.g., "daily")
+	Task       string `yaml:"task"`                 // Jira task key (e.g., "PROJ-123")
+	Time       string `yaml:"time"`                 // Optional: predefined time (e.g., "30m")
+	Label      string `yaml:"label"`                // Work log label
+	Transition string `yaml:"transition,omitempty"` // Optional: transition made after logging (e.g., "Done")
 }
 
 // DatabaseConfig contains SQLite database configuration (optional)
@@ -62,16 +109,115 @@ type DatabaseConfig struct {
 
 // SlackConfig contains Slack integration configuration (optional)
 type SlackConfig struct {
-	UserToken string       `yaml:"user_token"` // Slack user OAuth token (optional)
-	ChannelID string       `yaml:"channel_id"` // Channel ID for break messages (optional)
-	Breaks    []BreakEntry `yaml:"breaks"`     // Predefined break types (optional)
+	UserToken   string           `yaml:"user_token"`   // Slack user OAuth token (optional)
+	ChannelID   string           `yaml:"channel_id"`   // Channel ID for break messages (optional)
+	DailyThread bool             `yaml:"daily_thread"` // Post break messages as replies in one thread per day (default: false)
+	Breaks      []BreakEntry     `yaml:"breaks"`       // Predefined break types (optional)
+	TaskStatus  TaskStatusConfig `yaml:"task_status"`  // Mirror the current task in your Slack status (optional)
+}
+
+// TaskStatusConfig controls the "Working on PROJ-123" Slack status (optional)
+type TaskStatusConfig struct {
+	Enabled  bool   `yaml:"enabled"`  // Set the status on 'tasklog start' and 'tasklog log' (default: false)
+	Emoji    string `yaml:"emoji"`    // Status emoji (default: ":computer:")
+	Duration int    `yaml:"duration"` // Minutes the status is kept after 'tasklog log' (default: 30)
 }
 
 // BreakEntry represents a predefined break type (optional)
 type BreakEntry struct {
-	Name     string `yaml:"name"`     // Break name (e.g., "lunch", "prayer")
-	Duration int    `yaml:"duration"` // Duration in minutes
-	Emoji    string `yaml:"emoji"`    // Emoji for Slack status (optional)
+	Name     string         `yaml:"name"`               // Break name (e.g., "lunch", "prayer")
+	Duration int            `yaml:"duration"`           // Duration in minutes
+	Emoji    string         `yaml:"emoji"`              // Emoji for Slack status (optional)
+	Schedule *BreakSchedule `yaml:"schedule,omitempty"` // When to take the break automatically (optional)
+	Snooze   bool           `yaml:"snooze,omitempty"`   // Pause Slack notifications during the break (optional)
+	Away     bool           `yaml:"away,omitempty"`     // Set Slack presence to away during the break (optional)
+}
+
+// Notifier types for break notifications
+const (
+	NotifierSlack        = "slack"         // Slack user token: status and messages
+	NotifierSlackWebhook = "slack-webhook" // Slack incoming webhook: messages only
+	NotifierMattermost   = "mattermost"    // Mattermost incoming webhook: messages only
+	NotifierTeams        = "teams"         // Microsoft Teams incoming webhook: messages only
+)
+
+// NotifierConfig selects how break notifications are sent (optional)
+type NotifierConfig struct {
+	Type       string `yaml:"type" validate:"omitempty,oneof=slack slack-webhook mattermost teams"` // Notifier type (optional, defaults to "slack")
+	WebhookURL string `yaml:"webhook_url"`                                                          // Incoming webhook URL or secret reference (required for webhook types)
+}
+
+// IsWebhook reports whether the notifier posts through an incoming webhook
+func (n NotifierConfig) IsWebhook() bool {
+	return n.Type == NotifierSlackWebhook || n.Type == NotifierMattermost || n.Type == NotifierTeams
+}
+
+// TimeConfig controls how time input is rounded and how long a day is (optional)
+type TimeConfig struct {
+	RoundTo   int    `yaml:"round_to"`                                              // Rounding granularity in minutes (default: 5)
+	RoundMode string `yaml:"round_mode" validate:"omitempty,oneof=nearest up down"` // Rounding direction (default: "nearest")
+	Workday   string `yaml:"workday"`                                               // Length of "1d", like "7h30m" (defaults to report.daily_target)
+}
+
+// TimeRules returns the rounding and workday rules for parsing time input
+func (t TimeConfig) TimeRules() timeparse.Rules {
+	rules := timeparse.DefaultRules
+	if t.RoundTo > 0 {
+		rules.RoundTo = time.Duration(t.RoundTo) * time.Minute
+	}
+	if t.RoundMode != "" {
+		rules.RoundMode = timeparse.RoundMode(t.RoundMode)
+	}
+	if workday, err := str2duration.ParseDuration(t.Workday); err == nil && workday > 0 {
+		rules.Workday = workday
+	}
+	return rules
+}
+
+// ChecksConfig sets how sanity checks before saving a worklog react: "off", "warn" or "block" (optional)
+type ChecksConfig struct {
+	Overlap    string `yaml:"overlap" validate:"omitempty,oneof=off warn block"`     // Worklogs overlapping in time (default: "warn")
+	DailyTotal string `yaml:"daily_total" validate:"omitempty,oneof=off warn block"` // Days with more than daily_max logged (default: "warn")
+	DailyMax   string `yaml:"daily_max"`                                             // Most work per day, like "12h" (default: "12h")
+	Weekend    string `yaml:"weekend" validate:"omitempty,oneof=off warn block"`     // Worklogs on Saturday or Sunday (default: "warn")
+	Future     string `yaml:"future" validate:"omitempty,oneof=off warn block"`      // Worklogs starting or ending in the future (default: "block")
+	Tempo      bool   `yaml:"tempo"`                                                 // Also check against Tempo worklogs (needs tempo.enabled)
+}
+
+// PolicyConfig contains worklog rules enforced by 'tasklog log' and 'tasklog sync' (optional)
+type PolicyConfig struct {
+	RequireComment      bool           `yaml:"require_comment"`       // Every worklog needs a comment (default: false)
+	RequireCommentTypes []string       `yaml:"require_comment_types"` // Issue types whose worklogs need a comment (e.g., ["Bug"])
+	MinCommentLength    int            `yaml:"min_comment_length"`    // Shortest allowed comment in characters (default: 0, no minimum)
+	MaxEntry            string         `yaml:"max_entry"`             // Longest single worklog, like "8h" (optional)
+	LabelProjects       []LabelProject `yaml:"label_projects"`        // Labels only allowed on some projects (optional)
+}
+
+// LabelProject restricts a label to some projects
+type LabelProject struct {
+	Label    string   `yaml:"label"`    // Label name (e.g., "billable")
+	Projects []string `yaml:"projects"` // Project keys the label may be used on (e.g., ["ACME"])
+}
+
+// TimerConfig contains idle detection settings for 'tasklog start' timers (optional)
+type TimerConfig struct {
+	IdleThreshold string `yaml:"idle_threshold"` // Inactivity before time counts as idle, like "5m" (default: "5m")
+	IdleCommand   string `yaml:"idle_command"`   // Command printing idle time in milliseconds, replaces built-in detection (optional)
+}
+
+// PomodoroConfig contains 'tasklog pomodoro' interval lengths in minutes (optional)
+type PomodoroConfig struct {
+	Work           int    `yaml:"work"`             // Work interval (default: 25)
+	ShortBreak     int    `yaml:"short_break"`      // Break after most work intervals (default: 5)
+	LongBreak      int    `yaml:"long_break"`       // Break after every long_break_every work intervals (default: 15)
+	LongBreakEvery int    `yaml:"long_break_every"` // Work intervals before a long break (default: 4)
+	Break          string `yaml:"break"`            // Break from slack.breaks used for long breaks (optional, e.g., "coffee")
+}
+
+// ReportConfig contains worklog report configuration (optional)
+type ReportConfig struct {
+	DailyTarget string `yaml:"daily_target"` // Expected work per day (e.g., "8h"); weekly target is five times this (default: "8h")
+	ChannelID   string `yaml:"channel_id"`   // Channel for 'tasklog report post' (optional, defaults to slack.channel_id)
 }
 
 // UpdateConfig contains update checking configuration (optional)
@@ -104,6 +250,12 @@ func Load() (*Config, error) {
 	}
 
 	// Set defaults
+	if config.Jira.Deployment == "" {
+		config.Jira.Deployment = JiraCloud
+	}
+	if config.OAuth.RedirectPort == 0 {
+		config.OAuth.RedirectPort = 8765
+	}
 	if config.Database.Path == "" {
 		config.Database.Path = filepath.Join(getDefaultConfigDir(), "tasklog.db")
 	}
@@ -114,6 +266,71 @@ func Load() (*Config, error) {
 	}
 	// Disabled defaults to false (meaning update checks are enabled by default)
 
+	// Set report defaults
+	if config.Report.DailyTarget == "" {
+		config.Report.DailyTarget = "8h"
+	}
+
+	// Set Slack task status defaults
+	if config.Slack.TaskStatus.Emoji == "" {
+		config.Slack.TaskStatus.Emoji = ":computer:"
+	}
+	if config.Slack.TaskStatus.Duration == 0 {
+		config.Slack.TaskStatus.Duration = 30
+	}
+
+	// Set time input defaults
+	if config.Time.RoundTo == 0 {
+		config.Time.RoundTo = 5
+	}
+	if config.Time.RoundMode == "" {
+		config.Time.RoundMode = string(timeparse.RoundNearest)
+	}
+	if config.Time.Workday == "" {
+		config.Time.Workday = config.Report.DailyTarget
+	}
+
+	// Set check defaults
+	if config.Checks.Overlap == "" {
+		config.Checks.Overlap = "warn"
+	}
+	if config.Checks.DailyTotal == "" {
+		config.Checks.DailyTotal = "warn"
+	}
+	if config.Checks.DailyMax == "" {
+		config.Checks.DailyMax = "12h"
+	}
+	if config.Checks.Weekend == "" {
+		config.Checks.Weekend = "warn"
+	}
+	if config.Checks.Future == "" {
+		config.Checks.Future = "block"
+	}
+
+	// Set timer defaults
+	if config.Timer.IdleThreshold == "" {
+		config.Timer.IdleThreshold = "5m"
+	}
+
+	// Set pomodoro defaults
+	if config.Pomodoro.Work == 0 {
+		config.Pomodoro.Work = 25
+	}
+	if config.Pomodoro.ShortBreak == 0 {
+		config.Pomodoro.ShortBreak = 5
+	}
+	if config.Pomodoro.LongBreak == 0 {
+		config.Pomodoro.LongBreak = 15
+	}
+	if config.Pomodoro.LongBreakEvery == 0 {
+		config.Pomodoro.LongBreakEvery = 4
+	}
+
+	// Resolve secret references (env:, file:, cmd:) in credential fields
+	if err := config.resolveSecrets(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -141,9 +358,8 @@ func (c *Config) Validate() error {
 					return fmt.Errorf("%s must be a valid URL", field)
 				case "email":
 					return fmt.Errorf("%s must be a valid email address", field)
-				case "required_if":
-					// Extract the field name from the parameter (e.g., "Enabled true" -> "enabled is true")
-					return fmt.Errorf("%s is required when %s.enabled is true", field, "tempo")
+				case "oneof":
+					return fmt.Errorf("%s must be one of: %s", field, strings.ReplaceAll(fieldErr.Param(), " ", ", "))
 				default:
 					return fmt.Errorf("%s failed validation: %s", field, fieldErr.Tag())
 				}
@@ -151,6 +367,130 @@ func (c *Config) Validate() error {
 		}
 		return err
 	}
+
+	if err := c.validateJira(); err != nil {
+		return err
+	}
+
+	if err := c.validateBreakSchedules(); err != nil {
+		return err
+	}
+
+	if c.Notifier.IsWebhook() && c.Notifier.WebhookURL == "" {
+		return fmt.Errorf("notifier.webhook_url is required when notifier.type is %s", c.Notifier.Type)
+	}
+
+	if c.Time.RoundTo < 0 || c.Time.RoundTo > 60 {
+		return fmt.Errorf("time.round_to must be between 1 and 60 minutes")
+	}
+	if c.Time.Workday != "" {
+		if workday, err := str2duration.ParseDuration(c.Time.Workday); err != nil || workday <= 0 {
+			return fmt.Errorf("time.workday must be a positive duration like \"8h\"")
+		}
+	}
+
+	if c.Checks.DailyMax != "" {
+		if dailyMax, err := str2duration.ParseDuration(c.Checks.DailyMax); err != nil || dailyMax <= 0 {
+			return fmt.Errorf("checks.daily_max must be a positive duration like \"12h\"")
+		}
+	}
+
+	if c.Timer.IdleThreshold != "" {
+		if threshold, err := str2duration.ParseDuration(c.Timer.IdleThreshold); err != nil || threshold <= 0 {
+			return fmt.Errorf("timer.idle_threshold must be a positive duration like \"5m\"")
+		}
+	}
+
+	if err := c.validatePomodoro(); err != nil {
+		return err
+	}
+
+	if err := c.validatePolicy(); err != nil {
+		return err
+	}
+
+	if c.Report.DailyTarget != "" {
+		if _, err := timeparse.Parse(c.Report.DailyTarget); err != nil {
+			return fmt.Errorf("report.daily_target: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateJira checks the credentials, the username format for Cloud and that Tempo and OAuth are only used with Jira Cloud
+func (c *Config) validateJira() error {
+	if c.Tempo.Enabled && c.Tempo.APIToken == "" && !c.OAuth.TempoEnabled() {
+		return fmt.Errorf("tempo.api_token is required when tempo.enabled is true")
+	}
+	if c.OAuth.RedirectPort < 0 || c.OAuth.RedirectPort > 65535 {
+		return fmt.Errorf("oauth.redirect_port must be between 1 and 65535")
+	}
+
+	// 'tasklog login' identifies the user, so no username or API token is needed
+	if c.OAuth.Enabled() {
+		if c.Jira.IsServer() {
+			return fmt.Errorf("oauth requires jira.deployment %s", JiraCloud)
+		}
+		return nil
+	}
+
+	if c.Jira.Username == "" {
+		return fmt.Errorf("jira.username is required")
+	}
+	if c.Jira.APIToken == "" {
+		return fmt.Errorf("jira.api_token is required")
+	}
+
+	if !c.Jira.IsServer() {
+		if err := validator.New().Var(c.Jira.Username, "email"); err != nil {
+			return fmt.Errorf("jira.username must be a valid email address")
+		}
+		return nil
+	}
+
+	// Tempo's API only works with Jira Cloud accounts
+	if c.Tempo.Enabled {
+		return fmt.Errorf("tempo.enabled requires jira.deployment %s", JiraCloud)
+	}
+	if c.Checks.Tempo {
+		return fmt.Errorf("checks.tempo requires jira.deployment %s", JiraCloud)
+	}
+	return nil
+}
+
+// validatePomodoro checks interval lengths and that the long break refers to a configured break
+func (c *Config) validatePomodoro() error {
+	p := c.Pomodoro
+	if p.Work < 0 || p.ShortBreak < 0 || p.LongBreak < 0 || p.LongBreakEvery < 0 {
+		return fmt.Errorf("pomodoro intervals must not be negative")
+	}
+	if p.Break != "" {
+		if _, found := c.GetBreak(p.Break); !found {
+			return fmt.Errorf("pomodoro.break: no break named %q in slack.breaks", p.Break)
+		}
+	}
+	return nil
+}
+
+// validatePolicy checks the policy limits and that restricted labels name their projects
+func (c *Config) validatePolicy() error {
+	p := c.Policy
+	if p.MinCommentLength < 0 {
+		return fmt.Errorf("policy.min_comment_length must not be negative")
+	}
+	if p.MaxEntry != "" {
+		if _, err := timeparse.Parse(p.MaxEntry); err != nil {
+			return fmt.Errorf("policy.max_entry: %w", err)
+		}
+	}
+	for i, lp := range p.LabelProjects {
+		if lp.Label == "" {
+			return fmt.Errorf("policy.label_projects[%d].label is required", i)
+		}
+		if len(lp.Projects) == 0 {
+			return fmt.Errorf("policy.label_projects[%d].projects must list at least one project for label %q", i, lp.Label)
+		}
+	}
 	return nil
 }
 
@@ -189,6 +529,11 @@ func convertFieldNameToYAMLPath(namespace string) string {
 	return result
 }
 
+// UsesTempo reports whether Tempo is enabled with an API token or a Tempo OAuth app
+func (c *Config) UsesTempo() bool {
+	return c.Tempo.Enabled && (c.Tempo.APIToken != "" || c.OAuth.TempoEnabled())
+}
+
 // GetShortcut returns a shortcut by name
 func (c *Config) GetShortcut(name string) (*ShortcutEntry, bool) {
 	for _, shortcut := range c.Jira.Shortcuts {
@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"tasklog/internal/timeparse"
 )
 
 func TestValidate(t *testing.T) {
@@ -104,6 +107,192 @@ func TestValidate(t *testing.T) {
 			wantError: true,
 			errorMsg:  "tempo.api_token is required when tempo.enabled is true",
 		},
+		{
+			name: "webhook notifier without url",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Notifier: NotifierConfig{
+					Type: NotifierMattermost,
+				},
+			},
+			wantError: true,
+			errorMsg:  "notifier.webhook_url is required when notifier.type is mattermost",
+		},
+		{
+			name: "unknown notifier type",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Notifier: NotifierConfig{
+					Type: "discord",
+				},
+			},
+			wantError: true,
+			errorMsg:  "notifier.type must be one of: slack, slack-webhook, mattermost, teams",
+		},
+		{
+			name: "pomodoro break not configured",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Pomodoro: PomodoroConfig{
+					Break: "nap",
+				},
+			},
+			wantError: true,
+			errorMsg:  `pomodoro.break: no break named "nap" in slack.breaks`,
+		},
+		{
+			name: "invalid idle threshold",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Timer: TimerConfig{
+					IdleThreshold: "soon",
+				},
+			},
+			wantError: true,
+			errorMsg:  `timer.idle_threshold must be a positive duration like "5m"`,
+		},
+		{
+			name: "invalid round mode",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Time: TimeConfig{
+					RoundMode: "sideways",
+				},
+			},
+			wantError: true,
+			errorMsg:  "time.round_mode must be one of: nearest, up, down",
+		},
+		{
+			name: "invalid workday",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Time: TimeConfig{
+					Workday: "all day",
+				},
+			},
+			wantError: true,
+			errorMsg:  `time.workday must be a positive duration like "8h"`,
+		},
+		{
+			name: "cloud username must be an email",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "jdoe",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: true,
+			errorMsg:  "jira.username must be a valid email address",
+		},
+		{
+			name: "server with username",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://jira.example.com",
+					Deployment: JiraServer,
+					Username:   "jdoe",
+					APIToken:   "personal-access-token",
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "invalid deployment",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://jira.example.com",
+					Deployment: "datacentre",
+					Username:   "jdoe",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: true,
+			errorMsg:  "jira.deployment must be one of: cloud, server",
+		},
+		{
+			name: "server with tempo",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://jira.example.com",
+					Deployment: JiraServer,
+					Username:   "jdoe",
+					APIToken:   "personal-access-token",
+					ProjectKey: "PROJ",
+				},
+				Tempo: TempoConfig{
+					Enabled:  true,
+					APIToken: "tempo-token",
+				},
+			},
+			wantError: true,
+			errorMsg:  "tempo.enabled requires jira.deployment cloud",
+		},
+		{
+			name: "oauth without username and api token",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					ProjectKey: "PROJ",
+				},
+				Tempo: TempoConfig{
+					Enabled: true,
+				},
+				OAuth: OAuthConfig{
+					ClientID:      "client",
+					TempoClientID: "tempo-client",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "oauth on server",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://jira.example.com",
+					Deployment: JiraServer,
+					ProjectKey: "PROJ",
+				},
+				OAuth: OAuthConfig{
+					ClientID: "client",
+				},
+			},
+			wantError: true,
+			errorMsg:  "oauth requires jira.deployment cloud",
+		},
 	}
 
 	for _, tt := range tests {
@@ -438,3 +627,14 @@ func TestEnsureConfigDir_RespectsEnvVar(t *testing.T) {
 		t.Errorf("expected directory %s to be created, but it does not exist", expectedDir)
 	}
 }
+
+func TestTimeRules(t *testing.T) {
+	rules := TimeConfig{RoundTo: 15, RoundMode: "up", Workday: "7h30m"}.TimeRules()
+	if rules.RoundTo != 15*time.Minute || rules.RoundMode != timeparse.RoundUp || rules.Workday != 7*time.Hour+30*time.Minute {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+
+	if rules := (TimeConfig{}).TimeRules(); rules != timeparse.DefaultRules {
+		t.Errorf("empty config should use the default rules, got %+v", rules)
+	}
+}
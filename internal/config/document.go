@@ -0,0 +1,530 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a parsed config file that can be edited without losing comments or formatting
+type Document struct {
+	path     string
+	original []byte // File content as loaded, used for backups
+	root     *yaml.Node
+}
+
+// LoadDocument reads the config file for editing
+func LoadDocument() (*Document, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config path: %w", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("config file not found at %s\nRun 'tasklog init' to create one", configPath)
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	doc.path = configPath
+	doc.original = data
+
+	return doc, nil
+}
+
+// ParseDocument parses YAML config data into an editable document
+func ParseDocument(data []byte) (*Document, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	// Empty file - start with an empty mapping
+	if root.Kind == 0 {
+		root = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file must contain a YAML mapping at the top level")
+	}
+
+	return &Document{root: &root}, nil
+}
+
+// Path returns the file path the document was loaded from
+func (d *Document) Path() string {
+	return d.path
+}
+
+// Get returns the value at a dotted path (e.g., "jira.task_statuses") rendered as YAML
+func (d *Document) Get(path string) (string, error) {
+	keys, err := splitPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := schemaType(keys); err != nil {
+		return "", err
+	}
+
+	node := lookupNode(d.mapping(), keys)
+	if node == nil {
+		return "", fmt.Errorf("%s is not set", path)
+	}
+
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+
+	out, err := encodeYAML(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set sets the value at a dotted path, creating parent sections as needed
+// The value is parsed as YAML (e.g., '["In Progress","Review"]' or 'true') unless the
+// target field is a string, in which case it is stored verbatim.
+func (d *Document) Set(path, value string) error {
+	keys, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+
+	fieldType, err := schemaType(keys)
+	if err != nil {
+		return err
+	}
+
+	valueNode, err := buildValueNode(fieldType, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", path, err)
+	}
+
+	parent, err := ensureParent(d.mapping(), keys[:len(keys)-1])
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+
+	last := keys[len(keys)-1]
+	if parent.Kind == yaml.SequenceNode {
+		index, _ := strconv.Atoi(last)
+		if index >= len(parent.Content) {
+			return fmt.Errorf("cannot set %s: index %d out of range", path, index)
+		}
+		replaceNode(parent.Content[index], valueNode)
+		return nil
+	}
+
+	if existing := mappingValue(parent, last); existing != nil {
+		replaceNode(existing, valueNode)
+		return nil
+	}
+
+	parent.Content = append(parent.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: last},
+		valueNode,
+	)
+	return nil
+}
+
+// Unset removes the value at a dotted path
+func (d *Document) Unset(path string) error {
+	keys, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := schemaType(keys); err != nil {
+		return err
+	}
+
+	parent := lookupNode(d.mapping(), keys[:len(keys)-1])
+	if parent == nil {
+		return fmt.Errorf("%s is not set", path)
+	}
+
+	last := keys[len(keys)-1]
+	switch parent.Kind {
+	case yaml.SequenceNode:
+		index, _ := strconv.Atoi(last)
+		if index >= len(parent.Content) {
+			return fmt.Errorf("%s is not set", path)
+		}
+		parent.Content = append(parent.Content[:index], parent.Content[index+1:]...)
+		return nil
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == last {
+				parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%s is not set", path)
+}
+
+// AddShortcut appends a shortcut to jira.shortcuts
+func (d *Document) AddShortcut(shortcut ShortcutEntry) error {
+	if shortcut.Name == "" {
+		return fmt.Errorf("shortcut name is required")
+	}
+	if shortcut.Task == "" {
+		return fmt.Errorf("shortcut task is required")
+	}
+
+	shortcuts, err := d.shortcutsNode()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range shortcuts.Content {
+		if name := mappingValue(item, "name"); name != nil && name.Value == shortcut.Name {
+			return fmt.Errorf("shortcut '%s' already exists", shortcut.Name)
+		}
+	}
+
+	var item yaml.Node
+	if err := item.Encode(shortcut); err != nil {
+		return fmt.Errorf("failed to encode shortcut: %w", err)
+	}
+	shortcuts.Content = append(shortcuts.Content, &item)
+
+	return nil
+}
+
+// RemoveShortcut removes a shortcut from jira.shortcuts by name
+func (d *Document) RemoveShortcut(name string) error {
+	shortcuts := lookupNode(d.mapping(), []string{"jira", "shortcuts"})
+	if shortcuts != nil && shortcuts.Kind == yaml.SequenceNode {
+		for i, item := range shortcuts.Content {
+			if nameNode := mappingValue(item, "name"); nameNode != nil && nameNode.Value == name {
+				shortcuts.Content = append(shortcuts.Content[:i], shortcuts.Content[i+1:]...)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("shortcut '%s' not found in configuration", name)
+}
+
+// Config decodes the document into a Config struct without resolving secrets or applying defaults
+func (d *Document) Config() (*Config, error) {
+	var cfg Config
+	if err := d.root.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks that the edited document still produces a valid configuration
+func (d *Document) Validate() error {
+	cfg, err := d.Config()
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
+
+// Bytes renders the document back to YAML
+func (d *Document) Bytes() ([]byte, error) {
+	return encodeYAML(d.root)
+}
+
+// Save validates the document and writes it back to the file it was loaded from
+// The file is written to a temporary file first and renamed, so a failed write
+// never leaves a truncated config behind.
+func (d *Document) Save() error {
+	if d.path == "" {
+		return fmt.Errorf("document has no file path")
+	}
+
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(d.path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary config file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, d.path); err != nil {
+		return fmt.Errorf("failed to replace config file: %w", err)
+	}
+
+	return nil
+}
+
+// mapping returns the top-level mapping node
+func (d *Document) mapping() *yaml.Node {
+	return d.root.Content[0]
+}
+
+// shortcutsNode returns the jira.shortcuts sequence, creating it if missing
+func (d *Document) shortcutsNode() (*yaml.Node, error) {
+	jira, err := ensureParent(d.mapping(), []string{"jira"})
+	if err != nil {
+		return nil, err
+	}
+
+	shortcuts := mappingValue(jira, "shortcuts")
+	if shortcuts == nil {
+		shortcuts = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		jira.Content = append(jira.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "shortcuts"},
+			shortcuts,
+		)
+	}
+
+	// An empty "shortcuts:" key is parsed as null
+	if shortcuts.Kind == yaml.ScalarNode && (shortcuts.Tag == "!!null" || shortcuts.Value == "") {
+		replaceNode(shortcuts, &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"})
+	}
+
+	if shortcuts.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("jira.shortcuts must be a list")
+	}
+	return shortcuts, nil
+}
+
+// splitPath splits a dotted path into its keys
+func splitPath(path string) ([]string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, fmt.Errorf("config key is required")
+	}
+
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("invalid config key: %s", path)
+		}
+	}
+	return keys, nil
+}
+
+// schemaType resolves a dotted path against the Config struct using yaml tags
+// Sequence elements are addressed by numeric index (e.g., jira.shortcuts.0.time)
+func schemaType(keys []string) (reflect.Type, error) {
+	current := reflect.TypeFor[Config]()
+
+	for i, key := range keys {
+		prefix := strings.Join(keys[:i+1], ".")
+
+		if current.Kind() == reflect.Pointer {
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByYAMLName(current, key)
+			if !ok {
+				return nil, fmt.Errorf("unknown config key: %s", prefix)
+			}
+			current = field.Type
+		case reflect.Slice:
+			if index, err := strconv.Atoi(key); err != nil || index < 0 {
+				return nil, fmt.Errorf("%s must be a list index", prefix)
+			}
+			current = current.Elem()
+		default:
+			return nil, fmt.Errorf("unknown config key: %s", prefix)
+		}
+	}
+
+	return current, nil
+}
+
+// fieldByYAMLName finds a struct field by its yaml tag name
+func fieldByYAMLName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tagName, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if tagName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// buildValueNode converts a command-line value into a YAML node matching the field type
+func buildValueNode(fieldType reflect.Type, value string) (*yaml.Node, error) {
+	var node *yaml.Node
+
+	if fieldType.Kind() == reflect.String {
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	} else {
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse value: %w", err)
+		}
+		if len(doc.Content) == 0 {
+			return nil, fmt.Errorf("value is empty")
+		}
+		node = doc.Content[0]
+		clearStyle(node)
+	}
+
+	// Check the value decodes into the target field type
+	target := reflect.New(fieldType)
+	if err := node.Decode(target.Interface()); err != nil {
+		return nil, fmt.Errorf("expected %s: %w", describeType(fieldType), err)
+	}
+
+	return node, nil
+}
+
+// clearStyle resets flow style so edited values are written in the file's block style
+func clearStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+// describeType returns a user-friendly name for a config field type
+func describeType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "a number"
+	case reflect.Slice:
+		return "a list (e.g., '[\"a\", \"b\"]')"
+	case reflect.Struct:
+		return "a mapping"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// lookupNode walks mappings and sequences along the given keys
+func lookupNode(node *yaml.Node, keys []string) *yaml.Node {
+	current := node
+	for _, key := range keys {
+		switch current.Kind {
+		case yaml.MappingNode:
+			current = mappingValue(current, key)
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(current.Content) {
+				return nil
+			}
+			current = current.Content[index]
+		default:
+			return nil
+		}
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// ensureParent walks the given keys creating empty mappings for missing sections
+func ensureParent(node *yaml.Node, keys []string) (*yaml.Node, error) {
+	current := node
+	for i, key := range keys {
+		prefix := strings.Join(keys[:i+1], ".")
+
+		switch current.Kind {
+		case yaml.MappingNode:
+			next := mappingValue(current, key)
+			if next == nil {
+				next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				current.Content = append(current.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+					next,
+				)
+			} else if next.Kind == yaml.ScalarNode && (next.Tag == "!!null" || next.Value == "") {
+				// Empty section like "tempo:" - turn it into a mapping
+				replaceNode(next, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+			}
+			current = next
+		case yaml.SequenceNode:
+			index, _ := strconv.Atoi(key)
+			if index >= len(current.Content) {
+				return nil, fmt.Errorf("%s does not exist", prefix)
+			}
+			current = current.Content[index]
+		default:
+			return nil, fmt.Errorf("%s is not a section", prefix)
+		}
+	}
+	return current, nil
+}
+
+// mappingValue returns the value node for a key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// replaceNode replaces a node's value in place while keeping its comments
+func replaceNode(target, value *yaml.Node) {
+	headComment, lineComment, footComment := target.HeadComment, target.LineComment, target.FootComment
+	*target = *value
+	target.HeadComment = headComment
+	target.LineComment = lineComment
+	target.FootComment = footComment
+}
+
+// encodeYAML marshals a node using the two-space indentation used by config files
+func encodeYAML(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,319 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const documentTestConfig = `# Tasklog config
+version: 1
+jira:
+  url: "https://example.atlassian.net"
+  username: "user@example.com"
+  api_token: "token123" # my token
+  project_key: "PROJ"
+  task_statuses:
+    - "In Progress"
+  shortcuts:
+    - name: "daily"
+      task: "PROJ-123"
+      time: "30m"
+      label: "meeting"
+tempo:
+  enabled: false
+`
+
+func mustParseDocument(t *testing.T, data string) *Document {
+	t.Helper()
+	doc, err := ParseDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("failed to parse document: %v", err)
+	}
+	return doc
+}
+
+func TestDocument_Get(t *testing.T) {
+	doc := mustParseDocument(t, documentTestConfig)
+
+	tests := []struct {
+		path      string
+		expected  string
+		wantError bool
+	}{
+		{path: "jira.project_key", expected: "PROJ"},
+		{path: "jira.task_statuses", expected: `- "In Progress"`},
+		{path: "jira.shortcuts.0.time", expected: "30m"},
+		{path: "tempo.enabled", expected: "false"},
+		{path: "slack.channel_id", wantError: true},
+		{path: "jira.unknown", wantError: true},
+		{path: "jira.shortcuts.first", wantError: true},
+		{path: "", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := doc.Get(tt.path)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDocument_Set(t *testing.T) {
+	t.Run("sets list value and keeps comments", func(t *testing.T) {
+		doc := mustParseDocument(t, documentTestConfig)
+
+		if err := doc.Set("jira.task_statuses", `["In Progress","Review"]`); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cfg, err := doc.Config()
+		if err != nil {
+			t.Fatalf("failed to decode config: %v", err)
+		}
+		if len(cfg.Jira.TaskStatuses) != 2 || cfg.Jira.TaskStatuses[1] != "Review" {
+			t.Errorf("expected task statuses to be updated, got %v", cfg.Jira.TaskStatuses)
+		}
+
+		data, err := doc.Bytes()
+		if err != nil {
+			t.Fatalf("failed to render document: %v", err)
+		}
+		output := string(data)
+		if !strings.Contains(output, "# Tasklog config") || !strings.Contains(output, "# my token") {
+			t.Errorf("expected comments to be preserved, got:\n%s", output)
+		}
+	})
+
+	t.Run("creates missing sections", func(t *testing.T) {
+		doc := mustParseDocument(t, documentTestConfig)
+
+		if err := doc.Set("slack.channel_id", "C123"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := doc.Get("slack.channel_id")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "C123" {
+			t.Errorf("expected C123, got %q", got)
+		}
+	})
+
+	t.Run("stores string fields verbatim", func(t *testing.T) {
+		doc := mustParseDocument(t, documentTestConfig)
+
+		if err := doc.Set("jira.project_key", "123"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cfg, err := doc.Config()
+		if err != nil {
+			t.Fatalf("failed to decode config: %v", err)
+		}
+		if cfg.Jira.ProjectKey != "123" {
+			t.Errorf("expected project key 123, got %q", cfg.Jira.ProjectKey)
+		}
+	})
+
+	t.Run("sets list element field", func(t *testing.T) {
+		doc := mustParseDocument(t, documentTestConfig)
+
+		if err := doc.Set("jira.shortcuts.0.time", "15m"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cfg, err := doc.Config()
+		if err != nil {
+			t.Fatalf("failed to decode config: %v", err)
+		}
+		if cfg.Jira.Shortcuts[0].Time != "15m" {
+			t.Errorf("expected shortcut time 15m, got %q", cfg.Jira.Shortcuts[0].Time)
+		}
+	})
+
+	errorTests := []struct {
+		name  string
+		path  string
+		value string
+	}{
+		{name: "unknown key", path: "jira.unknown", value: "x"},
+		{name: "wrong type for bool", path: "tempo.enabled", value: "maybe"},
+		{name: "wrong type for list", path: "jira.task_statuses", value: "{a: b}"},
+		{name: "index out of range", path: "jira.shortcuts.5.time", value: "15m"},
+		{name: "key below scalar", path: "jira.url.host", value: "x"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := mustParseDocument(t, documentTestConfig)
+			if err := doc.Set(tt.path, tt.value); err == nil {
+				t.Errorf("expected error setting %s=%s", tt.path, tt.value)
+			}
+		})
+	}
+}
+
+func TestDocument_Unset(t *testing.T) {
+	doc := mustParseDocument(t, documentTestConfig)
+
+	if err := doc.Unset("jira.task_statuses"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := doc.Get("jira.task_statuses"); err == nil {
+		t.Error("expected jira.task_statuses to be removed")
+	}
+
+	if err := doc.Unset("jira.task_statuses"); err == nil {
+		t.Error("expected error when unsetting missing key")
+	}
+
+	if err := doc.Unset("jira.shortcuts.0"); err != nil {
+		t.Fatalf("unexpected error removing list element: %v", err)
+	}
+	cfg, err := doc.Config()
+	if err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	if len(cfg.Jira.Shortcuts) != 0 {
+		t.Errorf("expected shortcuts to be empty, got %d", len(cfg.Jira.Shortcuts))
+	}
+}
+
+func TestDocument_Validate(t *testing.T) {
+	doc := mustParseDocument(t, documentTestConfig)
+
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("expected valid document, got %v", err)
+	}
+
+	if err := doc.Unset("jira.url"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := doc.Validate()
+	if err == nil {
+		t.Fatal("expected validation error after removing jira.url")
+	}
+	if !strings.Contains(err.Error(), "jira.url is required") {
+		t.Errorf("expected jira.url error, got %q", err.Error())
+	}
+}
+
+func TestDocument_Shortcuts(t *testing.T) {
+	doc := mustParseDocument(t, documentTestConfig)
+
+	err := doc.AddShortcut(ShortcutEntry{Name: "review", Task: "PROJ-456", Label: "code-review"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := doc.AddShortcut(ShortcutEntry{Name: "review", Task: "PROJ-789"}); err == nil {
+		t.Error("expected error for duplicate shortcut")
+	}
+	if err := doc.AddShortcut(ShortcutEntry{Name: "empty"}); err == nil {
+		t.Error("expected error for shortcut without task")
+	}
+
+	cfg, err := doc.Config()
+	if err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	shortcut, found := cfg.GetShortcut("review")
+	if !found || shortcut.Task != "PROJ-456" {
+		t.Fatalf("expected review shortcut to be added, got %+v", cfg.Jira.Shortcuts)
+	}
+
+	if err := doc.RemoveShortcut("daily"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := doc.RemoveShortcut("daily"); err == nil {
+		t.Error("expected error removing missing shortcut")
+	}
+
+	cfg, err = doc.Config()
+	if err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	if len(cfg.Jira.Shortcuts) != 1 || cfg.Jira.Shortcuts[0].Name != "review" {
+		t.Errorf("expected only review shortcut to remain, got %+v", cfg.Jira.Shortcuts)
+	}
+}
+
+func TestDocument_AddShortcutToEmptySection(t *testing.T) {
+	doc := mustParseDocument(t, "jira:\n  shortcuts:\n")
+
+	if err := doc.AddShortcut(ShortcutEntry{Name: "daily", Task: "PROJ-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := doc.Config()
+	if err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	if len(cfg.Jira.Shortcuts) != 1 {
+		t.Errorf("expected 1 shortcut, got %d", len(cfg.Jira.Shortcuts))
+	}
+}
+
+func TestDocument_Save(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(documentTestConfig), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("TASKLOG_CONFIG", configPath)
+
+	doc, err := LoadDocument()
+	if err != nil {
+		t.Fatalf("failed to load document: %v", err)
+	}
+
+	// Invalid edits must not be written
+	if err := doc.Set("jira.url", "not-a-url"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := doc.Save(); err == nil {
+		t.Fatal("expected save to fail validation")
+	}
+	data, _ := os.ReadFile(configPath)
+	if string(data) != documentTestConfig {
+		t.Error("expected config file to be unchanged after failed save")
+	}
+
+	if err := doc.Set("jira.url", "https://other.atlassian.net"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load saved config: %v", err)
+	}
+	if cfg.Jira.URL != "https://other.atlassian.net" {
+		t.Errorf("expected updated url, got %q", cfg.Jira.URL)
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatalf("failed to stat config: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,225 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration upgrades a config document from one schema version to the next
+type Migration struct {
+	From        int                                     // Version this migration upgrades from (to From+1)
+	Description string                                  // Short summary shown to the user
+	Apply       func(root *yaml.Node) ([]string, error) // Rewrites the top-level mapping and returns the changes made
+}
+
+// MigrationResult describes what a migration run changed
+type MigrationResult struct {
+	FromVersion int
+	ToVersion   int
+	Changes     []string
+}
+
+// migrations are the registered schema upgrade steps, one per version
+// To add a schema change: bump CurrentConfigVersion and append a Migration with From set to the previous version
+var migrations = []Migration{
+	{
+		From:        0,
+		Description: "Move shortcuts under jira, breaks under slack and invert update.check_for_updates",
+		Apply:       migrateV0ToV1,
+	},
+}
+
+// Version returns the schema version of the document (0 when unversioned)
+func (d *Document) Version() int {
+	node := mappingValue(d.mapping(), "version")
+	if node == nil {
+		return 0
+	}
+
+	version, err := strconv.Atoi(node.Value)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// NeedsMigration reports whether the document uses an older schema version
+func (d *Document) NeedsMigration() bool {
+	return d.Version() < CurrentConfigVersion
+}
+
+// PendingMigrations returns the migrations that would run for this document
+func (d *Document) PendingMigrations() []Migration {
+	var pending []Migration
+	for _, migration := range migrations {
+		if migration.From >= d.Version() && migration.From < CurrentConfigVersion {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// Migrate upgrades the document to CurrentConfigVersion by applying each registered step in order
+func (d *Document) Migrate() (*MigrationResult, error) {
+	from := d.Version()
+	if from > CurrentConfigVersion {
+		return nil, fmt.Errorf("config version %d is newer than supported version %d, please upgrade tasklog", from, CurrentConfigVersion)
+	}
+
+	result := &MigrationResult{FromVersion: from, ToVersion: from}
+
+	for version := from; version < CurrentConfigVersion; version++ {
+		migration, found := findMigration(version)
+		if !found {
+			return nil, fmt.Errorf("no migration registered from config version %d", version)
+		}
+
+		changes, err := migration.Apply(d.mapping())
+		if err != nil {
+			return nil, fmt.Errorf("migration from version %d failed: %w", version, err)
+		}
+
+		result.Changes = append(result.Changes, changes...)
+		result.ToVersion = version + 1
+		d.setVersion(result.ToVersion)
+	}
+
+	if result.ToVersion != result.FromVersion {
+		result.Changes = append(result.Changes, fmt.Sprintf("Set 'version' to %d", result.ToVersion))
+	}
+
+	return result, nil
+}
+
+// Backup writes the document's original file content next to the config file
+// Returns the backup file path
+func (d *Document) Backup() (string, error) {
+	if d.path == "" {
+		return "", fmt.Errorf("document has no file path")
+	}
+
+	backupPath := fmt.Sprintf("%s.%s.bak", d.path, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(backupPath, d.original, 0600); err != nil {
+		return "", fmt.Errorf("failed to write config backup: %w", err)
+	}
+
+	return backupPath, nil
+}
+
+// findMigration returns the migration registered for a source version
+func findMigration(from int) (Migration, bool) {
+	for _, migration := range migrations {
+		if migration.From == from {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// setVersion sets the version key, placing it at the top of the file when missing
+func (d *Document) setVersion(version int) {
+	root := d.mapping()
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)}
+
+	if existing := mappingValue(root, "version"); existing != nil {
+		replaceNode(existing, value)
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+
+	// Keep the file's header comment at the top
+	if len(root.Content) > 0 {
+		key.HeadComment = root.Content[0].HeadComment
+		root.Content[0].HeadComment = ""
+	}
+	root.Content = append([]*yaml.Node{key, value}, root.Content...)
+}
+
+// removeMappingKey removes a key from a mapping node and returns its value node
+func removeMappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value := node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return value
+		}
+	}
+	return nil
+}
+
+// migrateV0ToV1 applies the v1.0.0-alpha.5 and alpha.6 schema changes
+// - root 'shortcuts' moved to 'jira.shortcuts'
+// - root 'breaks' moved to 'slack.breaks'
+// - 'update.check_for_updates' replaced by 'update.disabled' (logic inverted)
+func migrateV0ToV1(root *yaml.Node) ([]string, error) {
+	var changes []string
+
+	moves := []struct {
+		key     string
+		section string
+	}{
+		{key: "shortcuts", section: "jira"},
+		{key: "breaks", section: "slack"},
+	}
+
+	for _, move := range moves {
+		value := removeMappingKey(root, move.key)
+		if value == nil {
+			continue
+		}
+
+		section, err := ensureParent(root, []string{move.section})
+		if err != nil {
+			return nil, err
+		}
+
+		target := fmt.Sprintf("%s.%s", move.section, move.key)
+		existing := mappingValue(section, move.key)
+
+		switch {
+		case existing == nil:
+			section.Content = append(section.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: move.key},
+				value,
+			)
+			changes = append(changes, fmt.Sprintf("Moved '%s' to '%s'", move.key, target))
+		case existing.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+			existing.Content = append(existing.Content, value.Content...)
+			changes = append(changes, fmt.Sprintf("Merged root '%s' into '%s'", move.key, target))
+		case existing.Kind == yaml.ScalarNode && existing.Tag == "!!null":
+			replaceNode(existing, value)
+			changes = append(changes, fmt.Sprintf("Moved '%s' to '%s'", move.key, target))
+		default:
+			return nil, fmt.Errorf("cannot move '%s': '%s' is not a list", move.key, target)
+		}
+	}
+
+	if update := mappingValue(root, "update"); update != nil {
+		if old := removeMappingKey(update, "check_for_updates"); old != nil {
+			var checkForUpdates bool
+			if err := old.Decode(&checkForUpdates); err != nil {
+				return nil, fmt.Errorf("update.check_for_updates must be true or false: %w", err)
+			}
+
+			if mappingValue(update, "disabled") == nil {
+				update.Content = append(update.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "disabled"},
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(!checkForUpdates)},
+				)
+				changes = append(changes, fmt.Sprintf("Replaced 'update.check_for_updates: %t' with 'update.disabled: %t'", checkForUpdates, !checkForUpdates))
+			} else {
+				changes = append(changes, "Removed deprecated 'update.check_for_updates' (update.disabled already set)")
+			}
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const unversionedTestConfig = `# My old config
+jira:
+  url: "https://example.atlassian.net"
+  username: "user@example.com"
+  api_token: "token123"
+  project_key: "PROJ"
+shortcuts:
+  - name: "daily"
+    task: "PROJ-123"
+    time: "30m"
+    label: "meeting"
+breaks:
+  - name: "lunch"
+    duration: 60
+update:
+  check_for_updates: true
+`
+
+func TestDocument_Version(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int
+	}{
+		{name: "unversioned", data: "jira:\n  url: x\n", expected: 0},
+		{name: "version 1", data: "version: 1\n", expected: 1},
+		{name: "invalid version", data: "version: abc\n", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := mustParseDocument(t, tt.data)
+			if got := doc.Version(); got != tt.expected {
+				t.Errorf("expected version %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDocument_Migrate(t *testing.T) {
+	doc := mustParseDocument(t, unversionedTestConfig)
+
+	if !doc.NeedsMigration() {
+		t.Fatal("expected unversioned config to need migration")
+	}
+	if len(doc.PendingMigrations()) != 1 {
+		t.Errorf("expected 1 pending migration, got %d", len(doc.PendingMigrations()))
+	}
+
+	result, err := doc.Migrate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.FromVersion != 0 || result.ToVersion != CurrentConfigVersion {
+		t.Errorf("expected migration 0 -> %d, got %d -> %d", CurrentConfigVersion, result.FromVersion, result.ToVersion)
+	}
+	if doc.NeedsMigration() {
+		t.Error("expected document to be up to date after migration")
+	}
+
+	expectedChanges := []string{
+		"Moved 'shortcuts' to 'jira.shortcuts'",
+		"Moved 'breaks' to 'slack.breaks'",
+		"Replaced 'update.check_for_updates: true' with 'update.disabled: false'",
+		"Set 'version' to 1",
+	}
+	if len(result.Changes) != len(expectedChanges) {
+		t.Fatalf("expected changes %v, got %v", expectedChanges, result.Changes)
+	}
+	for i, change := range expectedChanges {
+		if result.Changes[i] != change {
+			t.Errorf("expected change %q, got %q", change, result.Changes[i])
+		}
+	}
+
+	cfg, err := doc.Config()
+	if err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	if len(cfg.Jira.Shortcuts) != 1 || cfg.Jira.Shortcuts[0].Name != "daily" {
+		t.Errorf("expected shortcut under jira, got %+v", cfg.Jira.Shortcuts)
+	}
+	if len(cfg.Slack.Breaks) != 1 || cfg.Slack.Breaks[0].Name != "lunch" {
+		t.Errorf("expected break under slack, got %+v", cfg.Slack.Breaks)
+	}
+	if cfg.Update.Disabled {
+		t.Error("expected update.disabled to be false")
+	}
+
+	data, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("failed to render document: %v", err)
+	}
+	output := string(data)
+	if !strings.HasPrefix(output, "# My old config\nversion: 1\n") {
+		t.Errorf("expected version at the top below the header comment, got:\n%s", output)
+	}
+	if strings.Contains(output, "check_for_updates") {
+		t.Error("expected check_for_updates to be removed")
+	}
+}
+
+func TestDocument_MigrateMergesExistingShortcuts(t *testing.T) {
+	doc := mustParseDocument(t, `jira:
+  shortcuts:
+    - name: "existing"
+      task: "PROJ-1"
+shortcuts:
+  - name: "old"
+    task: "PROJ-2"
+`)
+
+	result, err := doc.Migrate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Changes[0] != "Merged root 'shortcuts' into 'jira.shortcuts'" {
+		t.Errorf("unexpected change: %q", result.Changes[0])
+	}
+
+	cfg, err := doc.Config()
+	if err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	if len(cfg.Jira.Shortcuts) != 2 {
+		t.Errorf("expected 2 shortcuts, got %d", len(cfg.Jira.Shortcuts))
+	}
+}
+
+func TestDocument_MigrateUpToDate(t *testing.T) {
+	doc := mustParseDocument(t, "version: 1\n")
+
+	result, err := doc.Migrate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Changes) != 0 {
+		t.Errorf("expected no changes, got %v", result.Changes)
+	}
+}
+
+func TestDocument_MigrateNewerVersion(t *testing.T) {
+	doc := mustParseDocument(t, "version: 99\n")
+
+	if _, err := doc.Migrate(); err == nil {
+		t.Error("expected error for config newer than supported")
+	}
+}
+
+func TestDocument_Backup(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(unversionedTestConfig), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("TASKLOG_CONFIG", configPath)
+
+	doc, err := LoadDocument()
+	if err != nil {
+		t.Fatalf("failed to load document: %v", err)
+	}
+
+	if _, err := doc.Migrate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	backupPath, err := doc.Backup()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	backup, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	if string(backup) != unversionedTestConfig {
+		t.Error("expected backup to contain the original config")
+	}
+
+	if err := doc.Save(); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load migrated config: %v", err)
+	}
+	if cfg.Version != CurrentConfigVersion || len(cfg.Jira.Shortcuts) != 1 {
+		t.Errorf("expected migrated config, got version %d with %d shortcuts", cfg.Version, len(cfg.Jira.Shortcuts))
+	}
+}
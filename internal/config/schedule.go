@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BreakSchedule describes when a break is taken automatically by 'tasklog break schedule run' (optional)
+type BreakSchedule struct {
+	Times  []string `yaml:"times,omitempty"`  // Fixed start times in 24h format (e.g., ["12:30"])
+	Window string   `yaml:"window,omitempty"` // Time range (e.g., "12:00-14:00"); reminded at the start, can be snoozed until the end
+	Days   []string `yaml:"days,omitempty"`   // Weekdays the schedule applies to (e.g., ["mon", "tue"]); empty means every day
+}
+
+// weekdayNames maps accepted weekday spellings to time.Weekday
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseClock parses a 24h "HH:MM" time of day into minutes since midnight
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM, e.g. 12:30)", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ParseWindow parses a "HH:MM-HH:MM" time range into start and end minutes since midnight
+func ParseWindow(value string) (int, int, error) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid window %q (expected HH:MM-HH:MM, e.g. 12:00-14:00)", value)
+	}
+
+	start, err := ParseClock(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := ParseClock(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	if end <= start {
+		return 0, 0, fmt.Errorf("invalid window %q: end must be after start", value)
+	}
+
+	return start, end, nil
+}
+
+// ParseWeekday parses a weekday name such as "mon" or "Monday"
+func ParseWeekday(value string) (time.Weekday, error) {
+	day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return 0, fmt.Errorf("invalid weekday %q (expected mon, tue, wed, thu, fri, sat or sun)", value)
+	}
+	return day, nil
+}
+
+// AppliesOn reports whether the schedule is active on the given weekday
+func (s *BreakSchedule) AppliesOn(day time.Weekday) bool {
+	if len(s.Days) == 0 {
+		return true
+	}
+	for _, name := range s.Days {
+		if d, err := ParseWeekday(name); err == nil && d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the schedule's times, window and weekdays
+func (s *BreakSchedule) validate() error {
+	if len(s.Times) == 0 && s.Window == "" {
+		return fmt.Errorf("times or window is required")
+	}
+	for _, value := range s.Times {
+		if _, err := ParseClock(value); err != nil {
+			return fmt.Errorf("times: %w", err)
+		}
+	}
+	if s.Window != "" {
+		if _, _, err := ParseWindow(s.Window); err != nil {
+			return fmt.Errorf("window: %w", err)
+		}
+	}
+	for _, name := range s.Days {
+		if _, err := ParseWeekday(name); err != nil {
+			return fmt.Errorf("days: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateBreakSchedules checks the schedule of every configured break
+func (c *Config) validateBreakSchedules() error {
+	for i, b := range c.Slack.Breaks {
+		if b.Schedule == nil {
+			continue
+		}
+		if err := b.Schedule.validate(); err != nil {
+			return fmt.Errorf("slack.breaks[%d].schedule (%s): %w", i, b.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{input: "12:30", expected: 750},
+		{input: "00:00", expected: 0},
+		{input: " 9:05 ", expected: 545},
+		{input: "09:05", expected: 545},
+		{input: "24:00", wantErr: true},
+		{input: "noon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseClock(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseClock(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.expected {
+				t.Errorf("ParseClock(%q) = %d, want %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	start, end, err := ParseWindow("12:00-14:30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if start != 720 || end != 870 {
+		t.Errorf("expected 720-870, got %d-%d", start, end)
+	}
+
+	for _, input := range []string{"12:00", "14:00-12:00", "12:00-12:00", "a-b"} {
+		if _, _, err := ParseWindow(input); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
+
+func TestBreakSchedule_AppliesOn(t *testing.T) {
+	everyDay := &BreakSchedule{Times: []string{"12:00"}}
+	if !everyDay.AppliesOn(time.Sunday) {
+		t.Error("expected schedule without days to apply every day")
+	}
+
+	weekdays := &BreakSchedule{Times: []string{"12:00"}, Days: []string{"Mon", "friday"}}
+	if !weekdays.AppliesOn(time.Monday) || !weekdays.AppliesOn(time.Friday) {
+		t.Error("expected schedule to apply on listed days")
+	}
+	if weekdays.AppliesOn(time.Tuesday) {
+		t.Error("expected schedule not to apply on unlisted days")
+	}
+}
+
+func TestValidate_BreakSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *BreakSchedule
+		errMsg   string
+	}{
+		{name: "valid times", schedule: &BreakSchedule{Times: []string{"12:30"}, Days: []string{"mon"}}},
+		{name: "valid window", schedule: &BreakSchedule{Window: "15:00-15:45"}},
+		{name: "empty", schedule: &BreakSchedule{}, errMsg: "times or window is required"},
+		{name: "bad time", schedule: &BreakSchedule{Times: []string{"25:00"}}, errMsg: "times: invalid time"},
+		{name: "bad window", schedule: &BreakSchedule{Window: "15:00"}, errMsg: "window: invalid window"},
+		{name: "bad day", schedule: &BreakSchedule{Times: []string{"12:00"}, Days: []string{"funday"}}, errMsg: "days: invalid weekday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token",
+					ProjectKey: "PROJ",
+				},
+				Slack: SlackConfig{
+					Breaks: []BreakEntry{{Name: "lunch", Duration: 60, Schedule: tt.schedule}},
+				},
+			}
+
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "slack.breaks[0].schedule (lunch)") {
+				t.Errorf("expected error to name the break, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Secret reference prefixes supported by credential fields
+// Example: api_token: "env:JIRA_TOKEN", "file:/run/secrets/jira" or "cmd:pass show jira"
+const (
+	secretEnvPrefix  = "env:"
+	secretFilePrefix = "file:"
+	secretCmdPrefix  = "cmd:"
+)
+
+// RedactedValue replaces plaintext secrets in displayed configuration
+const RedactedValue = "********"
+
+// secretCommandTimeout limits how long a cmd: reference may run
+const secretCommandTimeout = 10 * time.Second
+
+// SecretFields lists the yaml paths of configuration fields that hold credentials
+var SecretFields = []string{
+	"jira.api_token",
+	"tempo.api_token",
+	"oauth.client_secret",
+	"oauth.tempo_client_secret",
+	"slack.user_token",
+	"notifier.webhook_url",
+}
+
+// IsSecretReference reports whether a value points to an external secret source
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, secretEnvPrefix) ||
+		strings.HasPrefix(value, secretFilePrefix) ||
+		strings.HasPrefix(value, secretCmdPrefix)
+}
+
+// ResolveSecret resolves a secret reference to its actual value
+// Plain values (without env:, file: or cmd: prefix) are returned unchanged
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, secretEnvPrefix):
+		name := strings.TrimSpace(strings.TrimPrefix(value, secretEnvPrefix))
+		if name == "" {
+			return "", fmt.Errorf("env reference is missing a variable name")
+		}
+		secret, ok := os.LookupEnv(name)
+		if !ok || secret == "" {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+
+	case strings.HasPrefix(value, secretFilePrefix):
+		path := strings.TrimSpace(strings.TrimPrefix(value, secretFilePrefix))
+		if path == "" {
+			return "", fmt.Errorf("file reference is missing a path")
+		}
+		path = expandHome(path)
+		data, err := os.ReadFile(path) //nolint:gosec // G304: reading user-configured secret file is intended
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file %s: %w", path, err)
+		}
+		secret := strings.TrimSpace(string(data))
+		if secret == "" {
+			return "", fmt.Errorf("secret file %s is empty", path)
+		}
+		return secret, nil
+
+	case strings.HasPrefix(value, secretCmdPrefix):
+		command := strings.TrimSpace(strings.TrimPrefix(value, secretCmdPrefix))
+		if command == "" {
+			return "", fmt.Errorf("cmd reference is missing a command")
+		}
+		return runSecretCommand(command)
+	}
+
+	return value, nil
+}
+
+// runSecretCommand runs a shell command and returns its trimmed stdout
+func runSecretCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretCommandTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // G204: command comes from the user's own config file
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("secret command %q failed: %w", command, err)
+	}
+
+	// Commands like `pass show` may print extra lines, the secret is the first one
+	secret, _, _ := strings.Cut(strings.TrimSpace(stdout.String()), "\n")
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("secret command %q returned no output", command)
+	}
+	return secret, nil
+}
+
+// secretSource returns a short description of where a secret value comes from
+// It never includes the secret itself and is safe to use in logs
+func secretSource(value string) string {
+	switch {
+	case value == "":
+		return "unset"
+	case strings.HasPrefix(value, secretEnvPrefix):
+		return value
+	case strings.HasPrefix(value, secretFilePrefix):
+		return value
+	case strings.HasPrefix(value, secretCmdPrefix):
+		return "cmd"
+	}
+	return "plaintext"
+}
+
+// resolveSecrets replaces secret references in credential fields with their values
+func (c *Config) resolveSecrets() error {
+	fields := map[string]*string{
+		"jira.api_token":            &c.Jira.APIToken,
+		"tempo.api_token":           &c.Tempo.APIToken,
+		"oauth.client_secret":       &c.OAuth.ClientSecret,
+		"oauth.tempo_client_secret": &c.OAuth.TempoClientSecret,
+		"slack.user_token":          &c.Slack.UserToken,
+		"notifier.webhook_url":      &c.Notifier.WebhookURL,
+	}
+
+	for _, path := range SecretFields {
+		field := fields[path]
+		if !IsSecretReference(*field) {
+			log.Debug().Str("field", path).Str("source", secretSource(*field)).Msg("Using configured secret")
+			continue
+		}
+
+		secret, err := ResolveSecret(*field)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+
+		log.Debug().Str("field", path).Str("source", secretSource(*field)).Msg("Resolved secret reference")
+		*field = secret
+	}
+
+	return nil
+}
+
+// RedactSecrets returns the YAML config with plaintext credential values masked
+// Secret references are kept as-is since they don't contain secret material
+func RedactSecrets(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+
+	for _, path := range SecretFields {
+		node := lookupNode(doc.Content[0], strings.Split(path, "."))
+		if node == nil || node.Kind != yaml.ScalarNode || node.Value == "" || IsSecretReference(node.Value) {
+			continue
+		}
+		node.Value = RedactedValue
+		node.Style = yaml.DoubleQuotedStyle
+	}
+
+	return encodeYAML(&doc)
+}
+
+// expandHome expands a leading ~/ to the user's home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[2:])
+}
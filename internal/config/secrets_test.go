@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsSecretReference(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"env:JIRA_TOKEN", true},
+		{"file:/run/secrets/jira", true},
+		{"cmd:pass show jira", true},
+		{"plain-token", false},
+		{"", false},
+		{"ENV:JIRA_TOKEN", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsSecretReference(tt.value); got != tt.expected {
+				t.Errorf("IsSecretReference(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveSecret(t *testing.T) {
+	tmpDir := t.TempDir()
+	secretFile := filepath.Join(tmpDir, "jira")
+	if err := os.WriteFile(secretFile, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+	emptyFile := filepath.Join(tmpDir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0600); err != nil {
+		t.Fatalf("failed to write empty file: %v", err)
+	}
+
+	t.Setenv("TASKLOG_TEST_SECRET", "env-secret")
+
+	tests := []struct {
+		name      string
+		value     string
+		expected  string
+		wantError bool
+	}{
+		{name: "plain value", value: "plain-token", expected: "plain-token"},
+		{name: "env reference", value: "env:TASKLOG_TEST_SECRET", expected: "env-secret"},
+		{name: "env reference not set", value: "env:TASKLOG_TEST_MISSING", wantError: true},
+		{name: "env reference without name", value: "env:", wantError: true},
+		{name: "file reference", value: "file:" + secretFile, expected: "file-secret"},
+		{name: "file reference missing", value: "file:" + filepath.Join(tmpDir, "missing"), wantError: true},
+		{name: "file reference empty", value: "file:" + emptyFile, wantError: true},
+		{name: "cmd reference", value: "cmd:echo cmd-secret", expected: "cmd-secret"},
+		{name: "cmd reference uses first line", value: "cmd:printf 'first\\nsecond\\n'", expected: "first"},
+		{name: "cmd reference failing", value: "cmd:exit 1", wantError: true},
+		{name: "cmd reference no output", value: "cmd:true", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveSecret(tt.value)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ResolvesSecretReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	secretFile := filepath.Join(tmpDir, "tempo")
+	if err := os.WriteFile(secretFile, []byte("tempo-secret"), 0600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	configData := `version: 1
+jira:
+  url: "https://example.atlassian.net"
+  username: "user@example.com"
+  api_token: "env:TASKLOG_TEST_JIRA_TOKEN"
+  project_key: "PROJ"
+tempo:
+  enabled: true
+  api_token: "file:` + secretFile + `"
+slack:
+  user_token: "cmd:echo xoxp-secret"
+`
+	if err := os.WriteFile(configPath, []byte(configData), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	t.Setenv("TASKLOG_CONFIG", configPath)
+	t.Setenv("TASKLOG_TEST_JIRA_TOKEN", "jira-secret")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if cfg.Jira.APIToken != "jira-secret" {
+		t.Errorf("expected jira token from env, got %q", cfg.Jira.APIToken)
+	}
+	if cfg.Tempo.APIToken != "tempo-secret" {
+		t.Errorf("expected tempo token from file, got %q", cfg.Tempo.APIToken)
+	}
+	if cfg.Slack.UserToken != "xoxp-secret" {
+		t.Errorf("expected slack token from command, got %q", cfg.Slack.UserToken)
+	}
+}
+
+func TestLoadConfig_UnresolvableSecretReference(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configData := `jira:
+  url: "https://example.atlassian.net"
+  username: "user@example.com"
+  api_token: "env:TASKLOG_TEST_UNSET_TOKEN"
+  project_key: "PROJ"
+`
+	if err := os.WriteFile(configPath, []byte(configData), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	t.Setenv("TASKLOG_CONFIG", configPath)
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("expected error for unresolvable secret reference")
+	}
+	if !strings.Contains(err.Error(), "jira.api_token") {
+		t.Errorf("expected error to mention jira.api_token, got %q", err.Error())
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	configData := `# My config
+jira:
+  url: "https://example.atlassian.net"
+  api_token: "plain-jira-token" # keep this comment
+tempo:
+  api_token: "env:TEMPO_TOKEN"
+slack:
+  user_token: ""
+`
+
+	redacted, err := RedactSecrets([]byte(configData))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := string(redacted)
+
+	if strings.Contains(output, "plain-jira-token") {
+		t.Error("expected plaintext jira token to be redacted")
+	}
+	if !strings.Contains(output, RedactedValue) {
+		t.Error("expected redacted placeholder in output")
+	}
+	if !strings.Contains(output, "env:TEMPO_TOKEN") {
+		t.Error("expected secret reference to be kept")
+	}
+	if !strings.Contains(output, "# keep this comment") || !strings.Contains(output, "# My config") {
+		t.Error("expected comments to be preserved")
+	}
+	if !strings.Contains(output, "https://example.atlassian.net") {
+		t.Error("expected non-secret values to be kept")
+	}
+}
@@ -0,0 +1,131 @@
+package doctor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status is the outcome of a single health check
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// MaxClockSkew is the largest clock difference with Jira that is considered healthy
+const MaxClockSkew = 2 * time.Minute
+
+// Result is the outcome of a health check with a hint on how to fix failures
+type Result struct {
+	Name    string // Short check name (e.g., "Jira authentication")
+	Status  Status
+	Message string // What was found
+	Hint    string // How to fix it (shown for warnings and failures)
+}
+
+// Check is a named health check
+type Check struct {
+	Name string
+	Run  func() Result
+}
+
+// Pass returns a passing result
+func Pass(message string) Result {
+	return Result{Status: StatusPass, Message: message}
+}
+
+// Warn returns a warning result with a fix hint
+func Warn(message, hint string) Result {
+	return Result{Status: StatusWarn, Message: message, Hint: hint}
+}
+
+// Fail returns a failing result with a fix hint
+func Fail(message, hint string) Result {
+	return Result{Status: StatusFail, Message: message, Hint: hint}
+}
+
+// Skip returns a skipped result
+func Skip(message string) Result {
+	return Result{Status: StatusSkip, Message: message}
+}
+
+// Run executes checks in order and collects their results, named after the checks
+func Run(checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		result := check.Run()
+		result.Name = check.Name
+		results = append(results, result)
+	}
+	return results
+}
+
+// CheckClockSkew compares local time with the Jira server time
+func CheckClockSkew(serverTime, localTime time.Time) Result {
+	skew := localTime.Sub(serverTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	skew = skew.Round(time.Second)
+
+	if skew > MaxClockSkew {
+		direction := "ahead of"
+		if localTime.Before(serverTime) {
+			direction = "behind"
+		}
+		return Warn(
+			fmt.Sprintf("Local clock is %s %s Jira", skew, direction),
+			"Enable automatic time sync (NTP) so worklog start times are recorded correctly")
+	}
+
+	return Pass(fmt.Sprintf("Local clock is within %s of Jira", MaxClockSkew))
+}
+
+// HasFailures reports whether any check failed
+func HasFailures(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatResults renders results as a report with fix hints and a summary line
+func FormatResults(results []Result) string {
+	var sb strings.Builder
+
+	counts := make(map[Status]int)
+	for _, result := range results {
+		counts[result.Status]++
+
+		sb.WriteString(fmt.Sprintf("%s %-24s %s\n", statusIcon(result.Status), result.Name, result.Message))
+		if result.Hint != "" && (result.Status == StatusFail || result.Status == StatusWarn) {
+			sb.WriteString(fmt.Sprintf("   💡 %s\n", result.Hint))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%d passed, %d warnings, %d failed, %d skipped\n",
+		counts[StatusPass], counts[StatusWarn], counts[StatusFail], counts[StatusSkip]))
+
+	return sb.String()
+}
+
+// statusIcon returns the icon displayed for a status
+func statusIcon(status Status) string {
+	switch status {
+	case StatusPass:
+		return "✓"
+	case StatusWarn:
+		return "⚠"
+	case StatusFail:
+		return "✗"
+	case StatusSkip:
+		return "-"
+	}
+	return "?"
+}
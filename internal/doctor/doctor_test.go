@@ -0,0 +1,109 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_NamesResultsAfterChecks(t *testing.T) {
+	checks := []Check{
+		{Name: "First", Run: func() Result { return Pass("ok") }},
+		{Name: "Second", Run: func() Result { return Fail("broken", "fix it") }},
+	}
+
+	results := Run(checks)
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Name != "First" || results[1].Name != "Second" {
+		t.Errorf("expected results named after their checks, got %q and %q", results[0].Name, results[1].Name)
+	}
+	if results[1].Status != StatusFail || results[1].Hint != "fix it" {
+		t.Errorf("unexpected result: %+v", results[1])
+	}
+}
+
+func TestRun_ChecksShareStateInOrder(t *testing.T) {
+	loaded := false
+	checks := []Check{
+		{Name: "Load", Run: func() Result {
+			loaded = true
+			return Pass("loaded")
+		}},
+		{Name: "Use", Run: func() Result {
+			if !loaded {
+				return Skip("not loaded")
+			}
+			return Pass("used")
+		}},
+	}
+
+	results := Run(checks)
+	if results[1].Status != StatusPass {
+		t.Errorf("expected second check to see state from the first, got %s", results[1].Status)
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	server := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		local    time.Time
+		status   Status
+		contains string
+	}{
+		{name: "in sync", local: server.Add(10 * time.Second), status: StatusPass},
+		{name: "ahead", local: server.Add(5 * time.Minute), status: StatusWarn, contains: "5m0s ahead of"},
+		{name: "behind", local: server.Add(-3 * time.Minute), status: StatusWarn, contains: "3m0s behind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CheckClockSkew(server, tt.local)
+			if result.Status != tt.status {
+				t.Errorf("expected status %s, got %s (%s)", tt.status, result.Status, result.Message)
+			}
+			if tt.contains != "" && !strings.Contains(result.Message, tt.contains) {
+				t.Errorf("expected message to contain %q, got %q", tt.contains, result.Message)
+			}
+		})
+	}
+}
+
+func TestHasFailures(t *testing.T) {
+	if HasFailures([]Result{Pass(""), Warn("", ""), Skip("")}) {
+		t.Error("expected no failures")
+	}
+	if !HasFailures([]Result{Pass(""), Fail("", "")}) {
+		t.Error("expected failures")
+	}
+}
+
+func TestFormatResults(t *testing.T) {
+	results := Run([]Check{
+		{Name: "Config file", Run: func() Result { return Pass("/tmp/config.yaml") }},
+		{Name: "Unsynced entries", Run: func() Result { return Warn("2 entries not synced", "Run 'tasklog sync'") }},
+		{Name: "Jira authentication", Run: func() Result { return Fail("401", "Check jira.api_token") }},
+		{Name: "Slack authentication", Run: func() Result { return Skip("Slack not configured") }},
+	})
+
+	output := FormatResults(results)
+
+	expected := []string{
+		"✓ Config file",
+		"⚠ Unsynced entries",
+		"💡 Run 'tasklog sync'",
+		"✗ Jira authentication",
+		"💡 Check jira.api_token",
+		"- Slack authentication",
+		"1 passed, 1 warnings, 1 failed, 1 skipped",
+	}
+	for _, s := range expected {
+		if !strings.Contains(output, s) {
+			t.Errorf("expected output to contain %q, got:\n%s", s, output)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package idle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrUnavailable is returned when idle time or uptime can't be measured on this system
+var ErrUnavailable = errors.New("not available on this system")
+
+// Detector reports how long the user has been idle (no keyboard or mouse input)
+type Detector interface {
+	// Name describes the idle source (e.g., "X11 (xprintidle)")
+	Name() string
+	// IdleTime returns the time since the last input
+	IdleTime() (time.Duration, error)
+}
+
+// commandDetector reads the idle time from a command's output
+type commandDetector struct {
+	name  string
+	cmd   string
+	args  []string
+	parse func(output string) (time.Duration, error)
+}
+
+// Name describes the idle source
+func (d *commandDetector) Name() string {
+	return d.name
+}
+
+// IdleTime runs the command and parses its output
+func (d *commandDetector) IdleTime() (time.Duration, error) {
+	output, err := exec.Command(d.cmd, d.args...).Output() //nolint:gosec // G204: commands are built in or come from the user's own config
+	if err != nil {
+		return 0, fmt.Errorf("failed to run %s: %w", d.cmd, err)
+	}
+	return d.parse(string(output))
+}
+
+// NewCommandDetector returns a detector that runs a shell command printing the idle time in milliseconds
+// This is the fallback for desktops without built-in support (timer.idle_command)
+func NewCommandDetector(command string) Detector {
+	return &commandDetector{
+		name:  fmt.Sprintf("command (%s)", command),
+		cmd:   "sh",
+		args:  []string{"-c", command},
+		parse: parseMilliseconds,
+	}
+}
+
+// Detect returns the first idle source that works on this system
+// A custom command, if set, is used instead of the built-in sources:
+// GNOME on Wayland (Mutter idle monitor), X11 (xprintidle) and macOS (IOKit HIDIdleTime)
+func Detect(command string) (Detector, error) {
+	if command != "" {
+		return NewCommandDetector(command), nil
+	}
+
+	for _, detector := range builtinDetectors() {
+		if _, err := detector.IdleTime(); err != nil {
+			log.Debug().Err(err).Str("detector", detector.Name()).Msg("Idle source not available")
+			continue
+		}
+		return detector, nil
+	}
+	return nil, fmt.Errorf("idle time %w; set timer.idle_command to a command printing idle milliseconds", ErrUnavailable)
+}
+
+// builtinDetectors lists the idle sources that may work on this system, most specific first
+func builtinDetectors() []Detector {
+	var detectors []Detector
+
+	switch runtime.GOOS {
+	case "linux":
+		if os.Getenv("WAYLAND_DISPLAY") != "" && commandExists("gdbus") {
+			detectors = append(detectors, &commandDetector{
+				name: "Wayland (GNOME idle monitor)",
+				cmd:  "gdbus",
+				args: []string{"call", "--session",
+					"--dest", "org.gnome.Mutter.IdleMonitor",
+					"--object-path", "/org/gnome/Mutter/IdleMonitor/Core",
+					"--method", "org.gnome.Mutter.IdleMonitor.GetIdletime"},
+				parse: parseMutterIdletime,
+			})
+		}
+		if os.Getenv("DISPLAY") != "" && commandExists("xprintidle") {
+			detectors = append(detectors, &commandDetector{
+				name:  "X11 (xprintidle)",
+				cmd:   "xprintidle",
+				parse: parseMilliseconds,
+			})
+		}
+	case "darwin":
+		detectors = append(detectors, &commandDetector{
+			name:  "macOS (IOKit)",
+			cmd:   "ioreg",
+			args:  []string{"-c", "IOHIDSystem", "-d", "4"},
+			parse: parseIORegIdleTime,
+		})
+	}
+
+	return detectors
+}
+
+// commandExists reports whether a command is on the PATH
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// parseMilliseconds parses a plain number of milliseconds (xprintidle and custom commands)
+func parseMilliseconds(output string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf("expected idle milliseconds, got %q", strings.TrimSpace(output))
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// mutterPattern matches the GVariant reply of GetIdletime, e.g. "(uint64 12345,)"
+var mutterPattern = regexp.MustCompile(`uint64\s+(\d+)`)
+
+// parseMutterIdletime parses the reply of the GNOME Mutter idle monitor (milliseconds)
+func parseMutterIdletime(output string) (time.Duration, error) {
+	match := mutterPattern.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("unexpected idle monitor reply: %q", strings.TrimSpace(output))
+	}
+	return parseMilliseconds(match[1])
+}
+
+// ioregPattern matches the HIDIdleTime property in ioreg output (nanoseconds)
+var ioregPattern = regexp.MustCompile(`"HIDIdleTime"\s*=\s*(\d+)`)
+
+// parseIORegIdleTime parses HIDIdleTime from 'ioreg -c IOHIDSystem'
+func parseIORegIdleTime(output string) (time.Duration, error) {
+	match := ioregPattern.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("HIDIdleTime not found in ioreg output")
+	}
+	ns, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid HIDIdleTime %q: %w", match[1], err)
+	}
+	return time.Duration(ns), nil
+}
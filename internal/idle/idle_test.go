@@ -0,0 +1,125 @@
+package idle
+
+import (
+	"testing"
+	"time"
+
+	"tasklog/internal/storage"
+)
+
+func TestParsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func(string) (time.Duration, error)
+		output   string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{name: "xprintidle", parse: parseMilliseconds, output: "61500\n", expected: 61500 * time.Millisecond},
+		{name: "xprintidle garbage", parse: parseMilliseconds, output: "couldn't open display", wantErr: true},
+		{name: "mutter", parse: parseMutterIdletime, output: "(uint64 120000,)\n", expected: 2 * time.Minute},
+		{name: "mutter error", parse: parseMutterIdletime, output: "Error: GDBus.Error", wantErr: true},
+		{
+			name:     "ioreg",
+			parse:    parseIORegIdleTime,
+			output:   "  | |   \"HIDIdleTime\" = 300000000000\n  | |   \"HIDSomethingElse\" = 1\n",
+			expected: 5 * time.Minute,
+		},
+		{name: "ioreg missing", parse: parseIORegIdleTime, output: "nothing here", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.output)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCommandDetector(t *testing.T) {
+	detector, err := Detect("echo 90000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	idleTime, err := detector.IdleTime()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idleTime != 90*time.Second {
+		t.Errorf("expected 90s, got %s", idleTime)
+	}
+}
+
+func TestSuspendedBetween(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	uptime := 10 * time.Hour
+
+	tests := []struct {
+		name        string
+		startUptime time.Duration
+		end         time.Time
+		endUptime   time.Duration
+		expected    time.Duration
+	}{
+		{name: "no suspend", startUptime: uptime, end: start.Add(2 * time.Hour), endUptime: uptime + 2*time.Hour, expected: 0},
+		{name: "clock noise", startUptime: uptime, end: start.Add(2 * time.Hour), endUptime: uptime + 2*time.Hour - 20*time.Second, expected: 0},
+		{name: "suspended", startUptime: uptime, end: start.Add(2 * time.Hour), endUptime: uptime + 45*time.Minute, expected: 75 * time.Minute},
+		{name: "unknown start", startUptime: 0, end: start.Add(2 * time.Hour), endUptime: uptime, expected: 0},
+		{name: "rebooted", startUptime: uptime, end: start.Add(2 * time.Hour), endUptime: time.Hour, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuspendedBetween(start, tt.startUptime, tt.end, tt.endUptime); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTracker_Idle(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	tracker := NewTracker(5 * time.Minute)
+
+	// Active
+	if changed := tracker.Observe(start, 10*time.Second); len(changed) != 0 {
+		t.Fatalf("expected no segments while active, got %+v", changed)
+	}
+
+	// Idle for 6 minutes: a segment opens at the last input
+	changed := tracker.Observe(start.Add(6*time.Minute), 6*time.Minute)
+	if len(changed) != 1 || changed[0].Reason != storage.IdleReasonIdle || !changed[0].StartedAt.Equal(start) {
+		t.Fatalf("expected idle segment from the start, got %+v", changed)
+	}
+	segment := changed[0]
+	segment.ID = 1 // Stored by the caller
+
+	// Still idle: the same segment is extended
+	changed = tracker.Observe(start.Add(20*time.Minute), 20*time.Minute)
+	if len(changed) != 1 || changed[0] != segment || segment.Duration() != 20*time.Minute {
+		t.Fatalf("expected segment to be extended to 20m, got %+v", changed)
+	}
+
+	// Active again: the segment is closed at the last idle sample
+	changed = tracker.Observe(start.Add(21*time.Minute), 5*time.Second)
+	if len(changed) != 1 || changed[0] != segment || segment.Duration() != 20*time.Minute {
+		t.Fatalf("expected segment to be closed at 20m, got %+v", changed)
+	}
+
+	// Idle again later: a new segment
+	changed = tracker.Observe(start.Add(40*time.Minute), 8*time.Minute)
+	if len(changed) != 1 || changed[0] == segment || changed[0].ID != 0 {
+		t.Fatalf("expected a new segment, got %+v", changed)
+	}
+}
@@ -0,0 +1,29 @@
+package idle
+
+import "time"
+
+// MinSuspend is the smallest clock drift counted as suspend; smaller drift is clock adjustment noise
+const MinSuspend = time.Minute
+
+// SuspendedBetween returns how much of the wall-clock time between two readings the machine was suspended
+// Uptime readings come from Uptime, which stops while the machine sleeps. It returns 0 when the start
+// uptime is unknown (0) or the machine rebooted in between, since the drift can't be measured then.
+func SuspendedBetween(startWall time.Time, startUptime time.Duration, endWall time.Time, endUptime time.Duration) time.Duration {
+	if startUptime == 0 || endUptime < startUptime {
+		return 0
+	}
+
+	wall := endWall.Round(0).Sub(startWall.Round(0))
+	if wall <= 0 {
+		return 0
+	}
+
+	gap := wall - (endUptime - startUptime)
+	if gap < MinSuspend {
+		return 0
+	}
+	if gap > wall {
+		gap = wall
+	}
+	return gap
+}
@@ -0,0 +1,76 @@
+package idle
+
+import (
+	"time"
+
+	"tasklog/internal/storage"
+)
+
+// Tracker turns periodic idle samples into idle and suspend segments
+type Tracker struct {
+	threshold time.Duration
+	open      *storage.IdleSegment // Idle segment still in progress
+	floor     time.Time            // Idle time can't start before the end of the last suspend
+	last      time.Time            // Previous sample, with its monotonic reading
+}
+
+// NewTracker creates a tracker counting inactivity of at least threshold as idle
+func NewTracker(threshold time.Duration) *Tracker {
+	return &Tracker{threshold: threshold}
+}
+
+// Observe processes a sample taken at now (from time.Now) with the user idle for the given time
+// It returns the segments that were started, extended or closed by the sample, oldest first;
+// segments without an ID are new
+func (t *Tracker) Observe(now time.Time, idleTime time.Duration) []*storage.IdleSegment {
+	var changed []*storage.IdleSegment
+	wallNow := now.Round(0)
+
+	// The monotonic clock stops while the machine sleeps, so a wall clock that moved further means suspend
+	if !t.last.IsZero() {
+		gap := wallNow.Sub(t.last.Round(0)) - now.Sub(t.last)
+		if gap >= MinSuspend {
+			suspendStart := wallNow.Add(-gap)
+			if t.open != nil {
+				if t.open.EndedAt.After(suspendStart) {
+					t.open.EndedAt = suspendStart
+				}
+				changed = append(changed, t.open)
+				t.open = nil
+			}
+			changed = append(changed, &storage.IdleSegment{
+				StartedAt: suspendStart,
+				EndedAt:   wallNow,
+				Reason:    storage.IdleReasonSuspend,
+			})
+			t.floor = wallNow
+		}
+	}
+	t.last = now
+
+	idleStart := wallNow.Add(-idleTime)
+	if idleStart.Before(t.floor) {
+		idleStart = t.floor
+	}
+
+	if wallNow.Sub(idleStart) < t.threshold {
+		// Active again: the open segment ends at the last sample that was still idle
+		if t.open != nil {
+			changed = append(changed, t.open)
+			t.open = nil
+		}
+		return changed
+	}
+
+	// Input since the last sample means this is a new idle spell
+	if t.open != nil && idleStart.After(t.open.EndedAt) {
+		changed = append(changed, t.open)
+		t.open = nil
+	}
+
+	if t.open == nil {
+		t.open = &storage.IdleSegment{StartedAt: idleStart, Reason: storage.IdleReasonIdle}
+	}
+	t.open.EndedAt = wallNow
+	return append(changed, t.open)
+}
@@ -0,0 +1,18 @@
+package idle
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Uptime returns the time since boot, not counting time spent asleep
+func Uptime() (time.Duration, error) {
+	var ts unix.Timespec
+	// CLOCK_UPTIME_RAW stops while the machine is asleep, unlike CLOCK_MONOTONIC_RAW
+	if err := unix.ClockGettime(unix.CLOCK_UPTIME_RAW, &ts); err != nil {
+		return 0, fmt.Errorf("failed to read uptime clock: %w", err)
+	}
+	return time.Duration(ts.Nano()), nil
+}
@@ -0,0 +1,18 @@
+package idle
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Uptime returns the time since boot, not counting time spent suspended
+func Uptime() (time.Duration, error) {
+	var ts unix.Timespec
+	// CLOCK_MONOTONIC stops while the machine is suspended, unlike CLOCK_BOOTTIME
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		return 0, fmt.Errorf("failed to read monotonic clock: %w", err)
+	}
+	return time.Duration(ts.Nano()), nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package idle
+
+import "time"
+
+// Uptime is not supported on this platform, so suspend can't be detected across commands
+func Uptime() (time.Duration, error) {
+	return 0, ErrUnavailable
+}
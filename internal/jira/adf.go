@@ -0,0 +1,315 @@
+package jira
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ADFNode is a node of an Atlassian Document Format document
+type ADFNode struct {
+	Type    string         `json:"type"`
+	Version int            `json:"version,omitempty"` // Only set on the root "doc" node
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content []ADFNode      `json:"content,omitempty"`
+	Text    string         `json:"text,omitempty"`
+	Marks   []ADFMark      `json:"marks,omitempty"`
+}
+
+// ADFMark is a text formatting mark such as strong, em, code or link
+type ADFMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// ADFOptions controls auto-linking in MarkdownToADF
+type ADFOptions struct {
+	BaseURL     string   // Jira URL used to link issue keys; issue keys aren't linked when empty
+	ProjectKeys []string // Only link issue keys of these projects (all key-like words when empty)
+}
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	rulePattern    = regexp.MustCompile(`^(?:-{3,}|\*{3,}|_{3,})$`)
+	bulletPattern  = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	orderedPattern = regexp.MustCompile(`^(\d+)[.)]\s+(.*)$`)
+	quotePattern   = regexp.MustCompile(`^>\s?(.*)$`)
+	urlPattern     = regexp.MustCompile(`^https?://[^\s<>()]+`)
+	issuePattern   = regexp.MustCompile(`^([A-Z][A-Z0-9_]+)-\d+`)
+)
+
+// MarkdownToADF converts a markdown comment to an ADF document
+// Supports paragraphs (line breaks are kept), headings, bullet and numbered lists, quotes, fenced code,
+// rules, **bold**, *italic*, ~~strike~~, `code` and [links](url); URLs and issue keys are linked
+func MarkdownToADF(markdown string, opts ADFOptions) ADFNode {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	return ADFNode{Type: "doc", Version: 1, Content: parseBlocks(lines, opts)}
+}
+
+// parseBlocks converts markdown lines to ADF block nodes
+func parseBlocks(lines []string, opts ADFOptions) []ADFNode {
+	blocks := []ADFNode{}
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimSpace(lines[i])
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			block := ADFNode{Type: "codeBlock"}
+			if language := strings.TrimSpace(strings.TrimPrefix(trimmed, "```")); language != "" {
+				block.Attrs = map[string]any{"language": language}
+			}
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // Skip the closing fence
+			if text := strings.Join(code, "\n"); text != "" {
+				block.Content = []ADFNode{{Type: "text", Text: text}}
+			}
+			blocks = append(blocks, block)
+
+		case headingPattern.MatchString(trimmed):
+			match := headingPattern.FindStringSubmatch(trimmed)
+			blocks = append(blocks, ADFNode{
+				Type:    "heading",
+				Attrs:   map[string]any{"level": len(match[1])},
+				Content: parseInline(match[2], nil, opts),
+			})
+			i++
+
+		case rulePattern.MatchString(trimmed):
+			blocks = append(blocks, ADFNode{Type: "rule"})
+			i++
+
+		case bulletPattern.MatchString(trimmed):
+			list := ADFNode{Type: "bulletList"}
+			for ; i < len(lines) && bulletPattern.MatchString(strings.TrimSpace(lines[i])); i++ {
+				item := bulletPattern.FindStringSubmatch(strings.TrimSpace(lines[i]))[1]
+				list.Content = append(list.Content, listItem(item, opts))
+			}
+			blocks = append(blocks, list)
+
+		case orderedPattern.MatchString(trimmed):
+			list := ADFNode{Type: "orderedList"}
+			if start, _ := strconv.Atoi(orderedPattern.FindStringSubmatch(trimmed)[1]); start > 1 {
+				list.Attrs = map[string]any{"order": start}
+			}
+			for ; i < len(lines) && orderedPattern.MatchString(strings.TrimSpace(lines[i])); i++ {
+				item := orderedPattern.FindStringSubmatch(strings.TrimSpace(lines[i]))[2]
+				list.Content = append(list.Content, listItem(item, opts))
+			}
+			blocks = append(blocks, list)
+
+		case quotePattern.MatchString(trimmed):
+			var quoted []string
+			for ; i < len(lines) && quotePattern.MatchString(strings.TrimSpace(lines[i])); i++ {
+				quoted = append(quoted, quotePattern.FindStringSubmatch(strings.TrimSpace(lines[i]))[1])
+			}
+			if content := parseBlocks(quoted, opts); len(content) > 0 {
+				blocks = append(blocks, ADFNode{Type: "blockquote", Content: content})
+			}
+
+		default:
+			// A paragraph runs until a blank line or another block; its line breaks are kept
+			var content []ADFNode
+			for ; i < len(lines) && isParagraphLine(lines[i]); i++ {
+				if len(content) > 0 {
+					content = append(content, ADFNode{Type: "hardBreak"})
+				}
+				content = append(content, parseInline(strings.TrimSpace(lines[i]), nil, opts)...)
+			}
+			blocks = append(blocks, ADFNode{Type: "paragraph", Content: content})
+		}
+	}
+	return blocks
+}
+
+// isParagraphLine reports whether a line continues a paragraph rather than starting another block
+func isParagraphLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" &&
+		!strings.HasPrefix(trimmed, "```") &&
+		!headingPattern.MatchString(trimmed) &&
+		!rulePattern.MatchString(trimmed) &&
+		!bulletPattern.MatchString(trimmed) &&
+		!orderedPattern.MatchString(trimmed) &&
+		!quotePattern.MatchString(trimmed)
+}
+
+// listItem wraps inline markdown in a list item
+func listItem(text string, opts ADFOptions) ADFNode {
+	return ADFNode{Type: "listItem", Content: []ADFNode{{Type: "paragraph", Content: parseInline(text, nil, opts)}}}
+}
+
+// parseInline converts inline markdown to text nodes carrying marks
+func parseInline(text string, marks []ADFMark, opts ADFOptions) []ADFNode {
+	var nodes []ADFNode
+	var plain strings.Builder
+
+	flush := func() {
+		if plain.Len() > 0 {
+			nodes = append(nodes, textNode(plain.String(), marks))
+			plain.Reset()
+		}
+	}
+	// emit flushes pending text and adds the formatted nodes
+	emit := func(formatted ...ADFNode) {
+		flush()
+		nodes = append(nodes, formatted...)
+	}
+
+	inLink := hasMark(marks, "link")
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		prev, _ := utf8.DecodeLastRuneInString(text[:i])
+		atWordStart := i == 0 || !(unicode.IsLetter(prev) || unicode.IsDigit(prev) || prev == '-' || prev == '/')
+
+		if strings.HasPrefix(rest, "`") {
+			if end := strings.Index(rest[1:], "`"); end > 0 {
+				emit(textNode(rest[1:end+1], []ADFMark{{Type: "code"}}))
+				i += end + 2
+				continue
+			}
+		}
+
+		if delimiter, markType := emphasis(rest); delimiter != "" {
+			if end := closingDelimiter(rest[len(delimiter):], delimiter); end > 0 && (delimiter[0] != '_' || atWordStart) {
+				inner := rest[len(delimiter) : len(delimiter)+end]
+				emit(parseInline(inner, withMark(marks, ADFMark{Type: markType}), opts)...)
+				i += len(delimiter)*2 + end
+				continue
+			}
+		}
+
+		if strings.HasPrefix(rest, "[") && !inLink {
+			if label, href, length, ok := markdownLink(rest); ok {
+				emit(parseInline(label, withMark(marks, linkMark(href)), opts)...)
+				i += length
+				continue
+			}
+		}
+
+		if atWordStart && !inLink {
+			if url := urlPattern.FindString(rest); url != "" {
+				url = strings.TrimRight(url, ".,;:!?'\"")
+				emit(textNode(url, withMark(marks, linkMark(url))))
+				i += len(url)
+				continue
+			}
+
+			if match := issuePattern.FindStringSubmatch(rest); match != nil && opts.linksIssues(match[1]) {
+				next, _ := utf8.DecodeRuneInString(rest[len(match[0]):])
+				if !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+					emit(textNode(match[0], withMark(marks, linkMark(strings.TrimSuffix(opts.BaseURL, "/")+"/browse/"+match[0]))))
+					i += len(match[0])
+					continue
+				}
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		plain.WriteRune(r)
+		i += size
+	}
+	flush()
+	return nodes
+}
+
+// emphasis returns the emphasis delimiter starting text and its mark type
+func emphasis(text string) (string, string) {
+	for _, e := range []struct{ delimiter, mark string }{
+		{"**", "strong"}, {"__", "strong"}, {"~~", "strike"}, {"*", "em"}, {"_", "em"},
+	} {
+		if strings.HasPrefix(text, e.delimiter) {
+			return e.delimiter, e.mark
+		}
+	}
+	return "", ""
+}
+
+// closingDelimiter returns where the closing delimiter starts in text, or -1
+// The emphasized text can't start or end with a space
+func closingDelimiter(text, delimiter string) int {
+	if text == "" || text[0] == ' ' {
+		return -1
+	}
+	for i := 1; i < len(text); i++ {
+		if !strings.HasPrefix(text[i:], delimiter) || text[i-1] == ' ' {
+			continue
+		}
+		// A single * or _ must not be half of a double one
+		if len(delimiter) == 1 && i+1 < len(text) && text[i+1] == delimiter[0] {
+			i++
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// markdownLink parses "[label](href)" at the start of text
+func markdownLink(text string) (label, href string, length int, ok bool) {
+	closeLabel := strings.Index(text, "](")
+	if closeLabel < 2 {
+		return "", "", 0, false
+	}
+	closeHref := strings.Index(text[closeLabel+2:], ")")
+	if closeHref < 1 {
+		return "", "", 0, false
+	}
+	label = text[1:closeLabel]
+	href = strings.TrimSpace(text[closeLabel+2 : closeLabel+2+closeHref])
+	return label, href, closeLabel + 3 + closeHref, href != ""
+}
+
+// linksIssues reports whether keys of the project should be linked
+func (o ADFOptions) linksIssues(project string) bool {
+	if o.BaseURL == "" {
+		return false
+	}
+	if len(o.ProjectKeys) == 0 {
+		return true
+	}
+	for _, key := range o.ProjectKeys {
+		if strings.EqualFold(key, project) {
+			return true
+		}
+	}
+	return false
+}
+
+// textNode creates a text node, leaving out empty marks
+func textNode(text string, marks []ADFMark) ADFNode {
+	node := ADFNode{Type: "text", Text: text}
+	if len(marks) > 0 {
+		node.Marks = marks
+	}
+	return node
+}
+
+// linkMark creates a link mark
+func linkMark(href string) ADFMark {
+	return ADFMark{Type: "link", Attrs: map[string]any{"href": href}}
+}
+
+// withMark returns marks plus mark, without changing marks
+func withMark(marks []ADFMark, mark ADFMark) []ADFMark {
+	result := make([]ADFMark, 0, len(marks)+1)
+	result = append(result, marks...)
+	return append(result, mark)
+}
+
+// hasMark reports whether marks include the mark type
+func hasMark(marks []ADFMark, markType string) bool {
+	for _, mark := range marks {
+		if mark.Type == markType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarkdownToADF(t *testing.T) {
+	opts := ADFOptions{BaseURL: "https://example.atlassian.net", ProjectKeys: []string{"PROJ"}}
+
+	tests := []struct {
+		name     string
+		markdown string
+		expected string // JSON of the document content
+	}{
+		{
+			name:     "plain text",
+			markdown: "Fixed the build",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Fixed the build"}]}]`,
+		},
+		{
+			name:     "line breaks and paragraphs",
+			markdown: "First line\nSecond line\n\nNext paragraph",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"First line"},{"type":"hardBreak"},{"type":"text","text":"Second line"}]},` +
+				`{"type":"paragraph","content":[{"type":"text","text":"Next paragraph"}]}]`,
+		},
+		{
+			name:     "emphasis and code",
+			markdown: "**Bold**, *italic*, ~~gone~~ and `go test`",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Bold","marks":[{"type":"strong"}]},{"type":"text","text":", "},` +
+				`{"type":"text","text":"italic","marks":[{"type":"em"}]},{"type":"text","text":", "},` +
+				`{"type":"text","text":"gone","marks":[{"type":"strike"}]},{"type":"text","text":" and "},` +
+				`{"type":"text","text":"go test","marks":[{"type":"code"}]}]}]`,
+		},
+		{
+			name:     "underscores inside words",
+			markdown: "renamed snake_case_name and 2 * 3 * 4",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"renamed snake_case_name and 2 * 3 * 4"}]}]`,
+		},
+		{
+			name:     "bullet list",
+			markdown: "Done:\n- review\n* deploy",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Done:"}]},` +
+				`{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"review"}]}]},` +
+				`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"deploy"}]}]}]}]`,
+		},
+		{
+			name:     "ordered list starting at 3",
+			markdown: "3. three\n4. four",
+			expected: `[{"type":"orderedList","attrs":{"order":3},"content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"three"}]}]},` +
+				`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"four"}]}]}]}]`,
+		},
+		{
+			name:     "heading, quote and rule",
+			markdown: "## Notes\n> quoted\n---",
+			expected: `[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Notes"}]},` +
+				`{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]},{"type":"rule"}]`,
+		},
+		{
+			name:     "fenced code",
+			markdown: "```sql\nSELECT 1;\n  -- *not* markdown\n```",
+			expected: `[{"type":"codeBlock","attrs":{"language":"sql"},"content":[{"type":"text","text":"SELECT 1;\n  -- *not* markdown"}]}]`,
+		},
+		{
+			name:     "markdown link",
+			markdown: "See [the **docs**](https://docs.example.com)",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"See "},` +
+				`{"type":"text","text":"the ","marks":[{"type":"link","attrs":{"href":"https://docs.example.com"}}]},` +
+				`{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://docs.example.com"}},{"type":"strong"}]}]}]`,
+		},
+		{
+			name:     "bare URL without trailing punctuation",
+			markdown: "Logs at https://logs.example.com/run/42.",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Logs at "},` +
+				`{"type":"text","text":"https://logs.example.com/run/42","marks":[{"type":"link","attrs":{"href":"https://logs.example.com/run/42"}}]},{"type":"text","text":"."}]}]`,
+		},
+		{
+			name:     "issue keys of known projects",
+			markdown: "Paired on PROJ-12 (UTF-8 fix), not XPROJ-1",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Paired on "},` +
+				`{"type":"text","text":"PROJ-12","marks":[{"type":"link","attrs":{"href":"https://example.atlassian.net/browse/PROJ-12"}}]},` +
+				`{"type":"text","text":" (UTF-8 fix), not XPROJ-1"}]}]`,
+		},
+		{
+			name:     "unclosed emphasis stays literal",
+			markdown: "**half",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"**half"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := MarkdownToADF(tt.markdown, opts)
+			if doc.Type != "doc" || doc.Version != 1 {
+				t.Errorf("unexpected root node %s version %d", doc.Type, doc.Version)
+			}
+			content, err := json.Marshal(doc.Content)
+			if err != nil {
+				t.Fatalf("failed to marshal content: %v", err)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("content mismatch:\ngot:  %s\nwant: %s", content, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownToADF_LinksAllIssueKeysWithoutProjects(t *testing.T) {
+	doc := MarkdownToADF("OPS-7", ADFOptions{BaseURL: "https://example.atlassian.net/"})
+	marks := doc.Content[0].Content[0].Marks
+	if len(marks) != 1 || marks[0].Attrs["href"] != "https://example.atlassian.net/browse/OPS-7" {
+		t.Errorf("expected OPS-7 to be linked, got %+v", marks)
+	}
+
+	doc = MarkdownToADF("OPS-7", ADFOptions{})
+	if marks := doc.Content[0].Content[0].Marks; len(marks) != 0 {
+		t.Errorf("expected no link without a base URL, got %+v", marks)
+	}
+}
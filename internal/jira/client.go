@@ -11,19 +11,34 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// oauthAPIURL is where OAuth 2.0 apps reach a Jira Cloud site, followed by its cloud ID
+const oauthAPIURL = "https://api.atlassian.com/ex/jira/"
+
+// TokenSource provides OAuth access tokens, refreshing them as needed, and the cloud ID of the site
+// they were issued for
+type TokenSource interface {
+	AccessToken() (string, error)
+	CloudID() (string, error)
+}
+
 // Client represents a Jira API client
 type Client struct {
-	baseURL    string
+	baseURL    string // Empty with OAuth, where requests go through gatewayURL instead
+	siteURL    string // Browsable Jira URL, differs from baseURL with OAuth
+	gatewayURL string // OAuth API gateway, followed by the cloud ID from the token source
 	username   string
 	apiToken   string
+	tokens     TokenSource
 	projectKey string
+	server     bool // Jira Server or Data Center instead of Jira Cloud
 	httpClient *http.Client
 }
 
-// NewClient creates a new Jira API client
+// NewClient creates a new Jira Cloud API client, authenticating with email and API token
 func NewClient(baseURL, username, apiToken, projectKey string) *Client {
 	return &Client{
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		siteURL:    strings.TrimSuffix(baseURL, "/"),
 		username:   username,
 		apiToken:   apiToken,
 		projectKey: projectKey,
@@ -33,6 +48,46 @@ func NewClient(baseURL, username, apiToken, projectKey string) *Client {
 	}
 }
 
+// NewServerClient creates a client for Jira Server or Data Center, authenticating with a personal access token.
+// It uses REST API v2, where comments are plain text and users are identified by username
+func NewServerClient(baseURL, username, accessToken, projectKey string) *Client {
+	client := NewClient(baseURL, username, accessToken, projectKey)
+	client.server = true
+	return client
+}
+
+// NewOAuthClient creates a Jira Cloud client that authenticates with tokens from 'tasklog login'.
+// Requests go through the Atlassian API gateway for the site's cloud ID, looked up when the first request is made
+func NewOAuthClient(siteURL string, tokens TokenSource, projectKey string) *Client {
+	client := NewClient(siteURL, "", "", projectKey)
+	client.baseURL = ""
+	client.gatewayURL = oauthAPIURL
+	client.tokens = tokens
+	return client
+}
+
+// IsServer reports whether the client talks to Jira Server or Data Center
+func (c *Client) IsServer() bool {
+	return c.server
+}
+
+// apiURL builds a REST API URL for the deployment: v3 on Cloud, v2 on Server
+func (c *Client) apiURL(path string, args ...interface{}) string {
+	version := "3"
+	if c.server {
+		version = "2"
+	}
+	return fmt.Sprintf("%s/rest/api/%s%s", c.baseURL, version, fmt.Sprintf(path, args...))
+}
+
+// searchURL returns the JQL search endpoint; Cloud replaced /search with /search/jql
+func (c *Client) searchURL() string {
+	if c.server {
+		return c.apiURL("/search")
+	}
+	return c.apiURL("/search/jql")
+}
+
 // Issue represents a Jira issue
 type Issue struct {
 	ID     string      `json:"id"` // Numeric ID as string
@@ -42,10 +97,12 @@ type Issue struct {
 
 // IssueFields represents Jira issue fields
 type IssueFields struct {
-	Summary  string       `json:"summary"`
-	Status   IssueStatus  `json:"status"`
-	Assignee *IssueUser   `json:"assignee"`
-	Worklog  *WorklogList `json:"worklog,omitempty"`
+	Summary      string        `json:"summary"`
+	Status       IssueStatus   `json:"status"`
+	IssueType    IssueType     `json:"issuetype"`
+	Assignee     *IssueUser    `json:"assignee"`
+	TimeTracking *TimeTracking `json:"timetracking,omitempty"`
+	Worklog      *WorklogList  `json:"worklog,omitempty"`
 }
 
 // WorklogList represents the worklog field in issue response
@@ -58,9 +115,15 @@ type IssueStatus struct {
 	Name string `json:"name"`
 }
 
+// IssueType represents a Jira issue type (e.g., "Bug")
+type IssueType struct {
+	Name string `json:"name"`
+}
+
 // IssueUser represents a Jira user
 type IssueUser struct {
-	AccountID    string `json:"accountId"`
+	AccountID    string `json:"accountId"`      // Jira Cloud
+	Name         string `json:"name,omitempty"` // Username on Jira Server
 	DisplayName  string `json:"displayName"`
 	EmailAddress string `json:"emailAddress"`
 }
@@ -92,6 +155,35 @@ func (c *Client) GetInProgressIssues(statuses []string) ([]Issue, error) {
 		statuses = []string{"In Progress"}
 	}
 
+	issues, err := c.searchAssignedIssues(statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch in-progress issues: %w", err)
+	}
+
+	log.Debug().Int("count", len(issues)).Msg("Retrieved in-progress issues")
+	return issues, nil
+}
+
+// GetBlockedIssues retrieves issues assigned to the current user in one of the given blocked statuses
+func (c *Client) GetBlockedIssues(statuses []string) ([]Issue, error) {
+	log.Debug().Msg("Fetching blocked issues")
+
+	// Default to "Blocked" if no statuses provided
+	if len(statuses) == 0 {
+		statuses = []string{"Blocked"}
+	}
+
+	issues, err := c.searchAssignedIssues(statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blocked issues: %w", err)
+	}
+
+	log.Debug().Int("count", len(issues)).Msg("Retrieved blocked issues")
+	return issues, nil
+}
+
+// searchAssignedIssues finds issues assigned to the current user in the given statuses, most recently updated first
+func (c *Client) searchAssignedIssues(statuses []string) ([]Issue, error) {
 	// Build status filter
 	var statusFilter string
 	if len(statuses) == 1 {
@@ -111,20 +203,19 @@ func (c *Client) GetInProgressIssues(statuses []string) ([]Issue, error) {
 	jql = fmt.Sprintf("%s ORDER BY updated DESC", jql)
 
 	// Use POST method with JSON body as recommended by Jira API v3
-	endpoint := fmt.Sprintf("%s/rest/api/3/search/jql", c.baseURL)
+	endpoint := c.searchURL()
 
 	payload := map[string]interface{}{
 		"jql":        jql,
-		"fields":     []string{"summary", "status", "assignee"},
+		"fields":     []string{"summary", "status", "issuetype", "assignee", "timetracking"},
 		"maxResults": 50,
 	}
 
 	var result SearchResult
 	if err := c.doRequest("POST", endpoint, payload, &result); err != nil {
-		return nil, fmt.Errorf("failed to fetch in-progress issues: %w", err)
+		return nil, err
 	}
 
-	log.Debug().Int("count", len(result.Issues)).Msg("Retrieved in-progress issues")
 	return result.Issues, nil
 }
 
@@ -132,7 +223,7 @@ func (c *Client) GetInProgressIssues(statuses []string) ([]Issue, error) {
 func (c *Client) GetIssue(issueKey string) (*Issue, error) {
 	log.Debug().Str("key", issueKey).Msg("Fetching issue")
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/issue/%s?fields=summary,status,assignee", c.baseURL, issueKey)
+	endpoint := c.apiURL("/issue/%s?fields=summary,status,issuetype,assignee,timetracking", issueKey)
 
 	var issue Issue
 	if err := c.doRequest("GET", endpoint, nil, &issue); err != nil {
@@ -162,11 +253,11 @@ func (c *Client) SearchIssues(searchKey string) ([]Issue, error) {
 	jql = fmt.Sprintf("%s ORDER BY updated DESC", jql)
 
 	// Use POST method with JSON body as recommended by Jira API v3
-	endpoint := fmt.Sprintf("%s/rest/api/3/search/jql", c.baseURL)
+	endpoint := c.searchURL()
 
 	payload := map[string]interface{}{
 		"jql":        jql,
-		"fields":     []string{"summary", "status", "assignee"},
+		"fields":     []string{"summary", "status", "issuetype", "assignee", "timetracking"},
 		"maxResults": 20,
 	}
 
@@ -179,14 +270,18 @@ func (c *Client) SearchIssues(searchKey string) ([]Issue, error) {
 	return result.Issues, nil
 }
 
-// AddWorklog adds a worklog entry to an issue
-func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.Time, comment string) (*Worklog, error) {
+// AddWorklog adds a worklog entry to an issue, adjusting the remaining estimate as given
+func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.Time, comment string, adjust EstimateAdjustment) (*Worklog, error) {
 	log.Debug().
 		Str("issue", issueKey).
 		Int("seconds", timeSpentSeconds).
+		Str("estimate", adjust.String()).
 		Msg("Adding worklog")
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/issue/%s/worklog", c.baseURL, issueKey)
+	endpoint := c.apiURL("/issue/%s/worklog", issueKey)
+	if query := adjust.query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
 
 	// Format started time in Jira format
 	startedStr := started.Format("2006-01-02T15:04:05.000-0700")
@@ -197,20 +292,11 @@ func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.
 	}
 
 	if comment != "" {
-		payload["comment"] = map[string]interface{}{
-			"type":    "doc",
-			"version": 1,
-			"content": []map[string]interface{}{
-				{
-					"type": "paragraph",
-					"content": []map[string]interface{}{
-						{
-							"type": "text",
-							"text": comment,
-						},
-					},
-				},
-			},
+		if c.server {
+			// REST API v2 takes comments as wiki-style plain text
+			payload["comment"] = comment
+		} else {
+			payload["comment"] = MarkdownToADF(comment, c.adfOptions(issueKey))
 		}
 	}
 
@@ -227,6 +313,18 @@ func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.
 	return &worklog, nil
 }
 
+// adfOptions links issue keys of the configured project and of the issue's own project
+func (c *Client) adfOptions(issueKey string) ADFOptions {
+	opts := ADFOptions{BaseURL: c.siteURL}
+	if i := strings.LastIndex(issueKey, "-"); i > 0 {
+		opts.ProjectKeys = append(opts.ProjectKeys, issueKey[:i])
+	}
+	if c.projectKey != "" {
+		opts.ProjectKeys = append(opts.ProjectKeys, c.projectKey)
+	}
+	return opts
+}
+
 // GetTodayWorklogs retrieves today's worklogs for the current user
 func (c *Client) GetTodayWorklogs() ([]Worklog, error) {
 	log.Debug().Msg("Fetching today's worklogs")
@@ -240,7 +338,7 @@ func (c *Client) GetTodayWorklogs() ([]Worklog, error) {
 
 	log.Debug().Str("jql", jql).Msg("Using JQL query")
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/search/jql", c.baseURL)
+	endpoint := c.searchURL()
 	payload := map[string]interface{}{
 		"jql":        jql,
 		"fields":     []string{"worklog", "summary", "key"},
@@ -290,7 +388,7 @@ func (c *Client) GetTodayWorklogs() ([]Worklog, error) {
 			// Check if this worklog is by current user
 			isByCurrentUser := true
 			if currentUser != nil && wl.Author != nil {
-				isByCurrentUser = wl.Author.AccountID == currentUser.AccountID
+				isByCurrentUser = sameUser(wl.Author, currentUser)
 			}
 
 			log.Debug().
@@ -317,7 +415,7 @@ func (c *Client) GetTodayWorklogs() ([]Worklog, error) {
 func (c *Client) GetCurrentUser() (*IssueUser, error) {
 	log.Debug().Msg("Fetching current user information")
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/myself", c.baseURL)
+	endpoint := c.apiURL("/myself")
 
 	var user IssueUser
 	if err := c.doRequest("GET", endpoint, nil, &user); err != nil {
@@ -326,12 +424,215 @@ func (c *Client) GetCurrentUser() (*IssueUser, error) {
 
 	log.Debug().
 		Str("account_id", user.AccountID).
+		Str("name", user.Name).
 		Str("display_name", user.DisplayName).
 		Msg("Retrieved current user")
 
 	return &user, nil
 }
 
+// sameUser compares users by account ID, or by username on Jira Server where there are no account IDs
+func sameUser(a, b *IssueUser) bool {
+	if a.AccountID != "" || b.AccountID != "" {
+		return a.AccountID == b.AccountID
+	}
+	return a.Name == b.Name
+}
+
+// Project represents a Jira project
+type Project struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// GetProjects retrieves all projects visible to the current user
+func (c *Client) GetProjects() ([]Project, error) {
+	log.Debug().Msg("Fetching projects")
+
+	// Jira Server lists all projects at once
+	if c.server {
+		var projects []Project
+		if err := c.doRequest("GET", c.apiURL("/project"), nil, &projects); err != nil {
+			return nil, fmt.Errorf("failed to fetch projects: %w", err)
+		}
+		log.Debug().Int("count", len(projects)).Msg("Retrieved projects")
+		return projects, nil
+	}
+
+	var projects []Project
+	startAt := 0
+
+	for {
+		endpoint := c.apiURL("/project/search?orderBy=key&maxResults=50&startAt=%d", startAt)
+
+		var page struct {
+			Values []Project `json:"values"`
+			IsLast bool      `json:"isLast"`
+		}
+		if err := c.doRequest("GET", endpoint, nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch projects: %w", err)
+		}
+
+		projects = append(projects, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+		startAt += len(page.Values)
+	}
+
+	log.Debug().Int("count", len(projects)).Msg("Retrieved projects")
+	return projects, nil
+}
+
+// GetProjectStatuses retrieves the unique status names used by a project's issue types
+func (c *Client) GetProjectStatuses(projectKey string) ([]string, error) {
+	log.Debug().Str("project", projectKey).Msg("Fetching project statuses")
+
+	issueTypes, err := c.getIssueTypeStatuses(projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same status is usually shared by several issue types
+	seen := make(map[string]bool)
+	var statuses []string
+	for _, issueType := range issueTypes {
+		for _, status := range issueType.Statuses {
+			if !seen[status.Name] {
+				seen[status.Name] = true
+				statuses = append(statuses, status.Name)
+			}
+		}
+	}
+
+	log.Debug().Int("count", len(statuses)).Msg("Retrieved project statuses")
+	return statuses, nil
+}
+
+// GetIssueTypeStatuses retrieves the status names in the workflow of one issue type of a project
+func (c *Client) GetIssueTypeStatuses(projectKey, issueType string) ([]string, error) {
+	log.Debug().Str("project", projectKey).Str("type", issueType).Msg("Fetching issue type statuses")
+
+	issueTypes, err := c.getIssueTypeStatuses(projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range issueTypes {
+		if !strings.EqualFold(t.Name, issueType) {
+			continue
+		}
+		statuses := make([]string, 0, len(t.Statuses))
+		for _, status := range t.Statuses {
+			statuses = append(statuses, status.Name)
+		}
+		return statuses, nil
+	}
+	return nil, fmt.Errorf("project %s has no issue type %q", projectKey, issueType)
+}
+
+// issueTypeStatuses lists the statuses of one issue type's workflow
+type issueTypeStatuses struct {
+	Name     string        `json:"name"`
+	Statuses []IssueStatus `json:"statuses"`
+}
+
+// getIssueTypeStatuses retrieves the statuses of every issue type of a project
+func (c *Client) getIssueTypeStatuses(projectKey string) ([]issueTypeStatuses, error) {
+	endpoint := c.apiURL("/project/%s/statuses", projectKey)
+
+	var issueTypes []issueTypeStatuses
+	if err := c.doRequest("GET", endpoint, nil, &issueTypes); err != nil {
+		return nil, fmt.Errorf("failed to fetch statuses for project %s: %w", projectKey, err)
+	}
+	return issueTypes, nil
+}
+
+// GetLabels retrieves all labels defined in Jira
+func (c *Client) GetLabels() ([]string, error) {
+	log.Debug().Msg("Fetching labels")
+
+	if c.server {
+		return nil, fmt.Errorf("listing labels is not supported by Jira Server")
+	}
+
+	var labels []string
+	startAt := 0
+
+	for {
+		endpoint := c.apiURL("/label?maxResults=1000&startAt=%d", startAt)
+
+		var page struct {
+			Values []string `json:"values"`
+			IsLast bool     `json:"isLast"`
+		}
+		if err := c.doRequest("GET", endpoint, nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch labels: %w", err)
+		}
+
+		labels = append(labels, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+		startAt += len(page.Values)
+	}
+
+	log.Debug().Int("count", len(labels)).Msg("Retrieved labels")
+	return labels, nil
+}
+
+// GetProject retrieves a project by key
+func (c *Client) GetProject(projectKey string) (*Project, error) {
+	log.Debug().Str("project", projectKey).Msg("Fetching project")
+
+	endpoint := c.apiURL("/project/%s", projectKey)
+
+	var project Project
+	if err := c.doRequest("GET", endpoint, nil, &project); err != nil {
+		return nil, fmt.Errorf("failed to fetch project %s: %w", projectKey, err)
+	}
+
+	return &project, nil
+}
+
+// IsTimeTrackingEnabled reports whether time tracking is enabled in the Jira instance
+func (c *Client) IsTimeTrackingEnabled() (bool, error) {
+	log.Debug().Msg("Fetching Jira configuration")
+
+	endpoint := c.apiURL("/configuration")
+
+	var configuration struct {
+		TimeTrackingEnabled bool `json:"timeTrackingEnabled"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &configuration); err != nil {
+		return false, fmt.Errorf("failed to fetch Jira configuration: %w", err)
+	}
+
+	return configuration.TimeTrackingEnabled, nil
+}
+
+// GetServerTime retrieves the current time reported by the Jira server
+func (c *Client) GetServerTime() (time.Time, error) {
+	log.Debug().Msg("Fetching Jira server info")
+
+	endpoint := c.apiURL("/serverInfo")
+
+	var info struct {
+		ServerTime string `json:"serverTime"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &info); err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch server info: %w", err)
+	}
+
+	serverTime, err := time.Parse("2006-01-02T15:04:05.000-0700", info.ServerTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse server time %q: %w", info.ServerTime, err)
+	}
+
+	return serverTime, nil
+}
+
 // doRequest performs an HTTP request to the Jira API
 func (c *Client) doRequest(method, url string, body interface{}, result interface{}) error {
 	var reqBody io.Reader
@@ -343,12 +644,31 @@ func (c *Client) doRequest(method, url string, body interface{}, result interfac
 		reqBody = strings.NewReader(string(jsonData))
 	}
 
+	var accessToken string
+	if c.tokens != nil {
+		cloudID, err := c.tokens.CloudID()
+		if err != nil {
+			return err
+		}
+		if accessToken, err = c.tokens.AccessToken(); err != nil {
+			return err
+		}
+		url = c.gatewayURL + cloudID + url
+	}
+
 	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.SetBasicAuth(c.username, c.apiToken)
+	switch {
+	case c.tokens != nil:
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	case c.server:
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	default:
+		req.SetBasicAuth(c.username, c.apiToken)
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -2,8 +2,11 @@ package jira
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -293,3 +296,545 @@ func TestGetInProgressIssues_SingleCustomStatus(t *testing.T) {
 		t.Errorf("expected issue key TEST-789, got %s", issues[0].Key)
 	}
 }
+
+func TestGetProjects_Paginates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/project/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("startAt") == "0" {
+			w.Write([]byte(`{"values":[{"id":"1","key":"ALPHA","name":"Alpha"}],"isLast":false}`))
+			return
+		}
+		w.Write([]byte(`{"values":[{"id":"2","key":"BETA","name":"Beta"}],"isLast":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "")
+	projects, err := client.GetProjects()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(projects))
+	}
+	if projects[0].Key != "ALPHA" || projects[1].Key != "BETA" {
+		t.Errorf("unexpected projects: %+v", projects)
+	}
+}
+
+func TestGetProjectStatuses_Deduplicates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/project/PROJ/statuses" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"name":"Task","statuses":[{"name":"To Do"},{"name":"In Progress"},{"name":"Done"}]},
+			{"name":"Bug","statuses":[{"name":"To Do"},{"name":"In Review"},{"name":"Done"}]}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "PROJ")
+	statuses, err := client.GetProjectStatuses("PROJ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"To Do", "In Progress", "Done", "In Review"}
+	if len(statuses) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, statuses)
+	}
+	for i, status := range expected {
+		if statuses[i] != status {
+			t.Errorf("expected status %q at %d, got %q", status, i, statuses[i])
+		}
+	}
+}
+
+func TestGetIssueTypeStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"name":"Task","statuses":[{"name":"To Do"},{"name":"In Progress"},{"name":"Done"}]},
+			{"name":"Bug","statuses":[{"name":"To Do"},{"name":"In Review"},{"name":"Done"}]}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "PROJ")
+	statuses, err := client.GetIssueTypeStatuses("PROJ", "bug")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(statuses, ",") != "To Do,In Review,Done" {
+		t.Errorf("expected the Bug workflow statuses, got %v", statuses)
+	}
+
+	if _, err := client.GetIssueTypeStatuses("PROJ", "Epic"); err == nil {
+		t.Error("expected an error for an unknown issue type")
+	}
+}
+
+func TestGetLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/label" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":["development","meeting"],"isLast":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "PROJ")
+	labels, err := client.GetLabels()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(labels) != 2 || labels[0] != "development" {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+}
+
+func TestIsTimeTrackingEnabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/configuration" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"timeTrackingEnabled":true,"votingEnabled":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "PROJ")
+	enabled, err := client.IsTimeTrackingEnabled()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !enabled {
+		t.Error("expected time tracking to be enabled")
+	}
+}
+
+func TestGetServerTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/serverInfo" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"serverTime":"2025-01-02T10:30:00.000+0000"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "PROJ")
+	serverTime, err := client.GetServerTime()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := time.Date(2025, 1, 2, 10, 30, 0, 0, time.UTC)
+	if !serverTime.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, serverTime)
+	}
+}
+
+func TestGetProject_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errorMessages":["No project could be found with key 'NOPE'."]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "NOPE")
+	if _, err := client.GetProject("NOPE"); err == nil {
+		t.Error("expected error for missing project")
+	}
+}
+
+func TestGetIssue_IssueType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-7" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if fields := r.URL.Query().Get("fields"); !strings.Contains(fields, "issuetype") {
+			t.Errorf("expected issuetype in fields, got %q", fields)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"10007","key":"TEST-7","fields":{"summary":"Crash on save","issuetype":{"name":"Bug"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	issue, err := client.GetIssue("TEST-7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issue.Fields.IssueType.Name != "Bug" {
+		t.Errorf("expected issue type Bug, got %q", issue.Fields.IssueType.Name)
+	}
+}
+
+func TestGetBlockedIssues_DefaultStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		expectedJQL := "assignee = currentUser() AND status = 'Blocked' AND project = TEST ORDER BY updated DESC"
+		if payload["jql"] != expectedJQL {
+			t.Errorf("expected JQL:\n%s\ngot:\n%s", expectedJQL, payload["jql"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issues":[{"key":"TEST-9","fields":{"summary":"Waiting on API access"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	issues, err := client.GetBlockedIssues(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Key != "TEST-9" {
+		t.Errorf("unexpected issues: %+v", issues)
+	}
+}
+
+func TestAddWorklog_MarkdownComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-1/worklog" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var payload struct {
+			Comment ADFNode `json:"comment"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if payload.Comment.Type != "doc" || len(payload.Comment.Content) != 2 {
+			t.Fatalf("expected a doc with two blocks, got %+v", payload.Comment)
+		}
+		if list := payload.Comment.Content[1]; list.Type != "bulletList" || len(list.Content) != 2 {
+			t.Errorf("expected a bullet list with two items, got %+v", list)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"100"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	worklog, err := client.AddWorklog("TEST-1", 3600, time.Now(), "Billing notes:\n- call with client\n- TEST-2 follow-up", EstimateAdjustment{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if worklog.ID != "100" {
+		t.Errorf("expected worklog ID 100, got %s", worklog.ID)
+	}
+}
+
+func TestAddWorklog_AdjustEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		adjust   EstimateAdjustment
+		expected string
+	}{
+		{"auto", EstimateAdjustment{}, ""},
+		{"leave", EstimateAdjustment{Mode: EstimateLeave}, "adjustEstimate=leave"},
+		{"new", EstimateAdjustment{Mode: EstimateNew, Seconds: 9000}, "adjustEstimate=new&newEstimate=2h+30m"},
+		{"manual", EstimateAdjustment{Mode: EstimateManual, Seconds: 1800}, "adjustEstimate=manual&reduceBy=30m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.expected {
+					t.Errorf("expected query %q, got %q", tt.expected, r.URL.RawQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id":"100"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token", "TEST")
+			if _, err := client.AddWorklog("TEST-1", 3600, time.Now(), "", tt.adjust); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	var transitioned string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-1/transitions" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"transitions":[{"id":"21","name":"Start review","to":{"name":"In Review"}},{"id":"31","name":"Done","to":{"name":"Done"}}]}`))
+		case "POST":
+			var payload struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			transitioned = payload.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	transitions, err := client.GetTransitions("TEST-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transitions) != 2 || transitions[0].Label() != "Start review → In Review" || transitions[1].Label() != "Done" {
+		t.Fatalf("unexpected transitions: %+v", transitions)
+	}
+
+	// Transitions match by name or by target status
+	for _, name := range []string{"start review", "in review"} {
+		if found, ok := FindTransition(transitions, name); !ok || found.ID != "21" {
+			t.Errorf("FindTransition(%q) = %+v, %v", name, found, ok)
+		}
+	}
+	if _, ok := FindTransition(transitions, "Closed"); ok {
+		t.Error("expected no transition named Closed")
+	}
+
+	if err := client.TransitionIssue("TEST-1", "21"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transitioned != "21" {
+		t.Errorf("expected transition 21, got %q", transitioned)
+	}
+}
+
+func TestCreateIssue(t *testing.T) {
+	var fields map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rest/api/3/issue" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		fields = payload.Fields
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"10042","key":"TEST-42","self":"https://example.atlassian.net/rest/api/3/issue/10042"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	issue, err := client.CreateIssue(IssueDraft{
+		ProjectKey: "TEST",
+		IssueType:  "Task",
+		Summary:    "Helped ops with outage",
+		ParentKey:  "TEST-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if issue.Key != "TEST-42" || issue.Fields.Summary != "Helped ops with outage" || issue.Fields.IssueType.Name != "Task" {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+	if fields["summary"] != "Helped ops with outage" {
+		t.Errorf("unexpected summary: %v", fields["summary"])
+	}
+	for name, want := range map[string]string{"project": "TEST", "issuetype": "Task", "parent": "TEST-1"} {
+		field, _ := fields[name].(map[string]interface{})
+		if field["key"] != want && field["name"] != want {
+			t.Errorf("expected %s %q, got %v", name, want, fields[name])
+		}
+	}
+}
+
+func TestCreateIssue_WithoutParent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := new(strings.Builder)
+		if _, err := io.Copy(body, r.Body); err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+		if strings.Contains(body.String(), "parent") {
+			t.Errorf("expected no parent field, got %s", body.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"10043","key":"TEST-43"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	if _, err := client.CreateIssue(IssueDraft{ProjectKey: "TEST", IssueType: "Task", Summary: "Unplanned"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetIssueTypes_SkipsSubtasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/project/TEST" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key":"TEST","issueTypes":[{"name":"Task"},{"name":"Sub-task","subtask":true},{"name":"Bug"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	issueTypes, err := client.GetIssueTypes("TEST")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(issueTypes, ",") != "Task,Bug" {
+		t.Errorf("expected Task,Bug, got %v", issueTypes)
+	}
+}
+
+func TestServerClient_UsesAPIv2AndBearerToken(t *testing.T) {
+	var comment interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer pat-token" {
+			t.Errorf("expected bearer token, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			w.Write([]byte(`{"issues":[{"id":"1","key":"TEST-1","fields":{"summary":"On prem"}}],"total":1}`))
+		case "/rest/api/2/issue/TEST-1/worklog":
+			var payload map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			comment = payload["comment"]
+			w.Write([]byte(`{"id":"100"}`))
+		case "/rest/api/2/project":
+			w.Write([]byte(`[{"id":"1","key":"TEST","name":"Test"}]`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewServerClient(server.URL, "jdoe", "pat-token", "TEST")
+
+	issues, err := client.GetInProgressIssues(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "TEST-1" {
+		t.Errorf("unexpected issues: %+v", issues)
+	}
+
+	if _, err := client.AddWorklog("TEST-1", 3600, time.Now(), "Fixed **PROJ-1**", EstimateAdjustment{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comment != "Fixed **PROJ-1**" {
+		t.Errorf("expected plain text comment, got %v", comment)
+	}
+
+	projects, err := client.GetProjects()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Key != "TEST" {
+		t.Errorf("unexpected projects: %+v", projects)
+	}
+
+	if _, err := client.CreateIssue(IssueDraft{ProjectKey: "TEST", IssueType: "Task", Summary: "x", ParentKey: "TEST-9"}); err == nil {
+		t.Error("expected parent epics to be rejected on Jira Server")
+	}
+}
+
+func TestSameUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     IssueUser
+		expected bool
+	}{
+		{"same account", IssueUser{AccountID: "abc"}, IssueUser{AccountID: "abc"}, true},
+		{"different account", IssueUser{AccountID: "abc", Name: "jdoe"}, IssueUser{AccountID: "def", Name: "jdoe"}, false},
+		{"same server username", IssueUser{Name: "jdoe"}, IssueUser{Name: "jdoe"}, true},
+		{"different server username", IssueUser{Name: "jdoe"}, IssueUser{Name: "asmith"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameUser(&tt.a, &tt.b); got != tt.expected {
+				t.Errorf("sameUser() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// staticTokens is a TokenSource with a fixed token or error
+type staticTokens struct {
+	token string
+	err   error
+}
+
+func (s staticTokens) AccessToken() (string, error) {
+	return s.token, s.err
+}
+
+func (s staticTokens) CloudID() (string, error) {
+	return "cloud-id", s.err
+}
+
+func TestOAuthClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer oauth-access" {
+			t.Errorf("expected OAuth bearer token, got %q", auth)
+		}
+		if r.URL.Path != "/ex/jira/cloud-id/rest/api/3/myself" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accountId":"abc","displayName":"Jane"}`))
+	}))
+	defer server.Close()
+
+	client := NewOAuthClient("https://mycompany.atlassian.net/", staticTokens{token: "oauth-access"}, "TEST")
+	if client.gatewayURL != "https://api.atlassian.com/ex/jira/" {
+		t.Errorf("unexpected gateway URL %q", client.gatewayURL)
+	}
+	if opts := client.adfOptions("TEST-1"); opts.BaseURL != "https://mycompany.atlassian.net" {
+		t.Errorf("expected issue links to the site, got %q", opts.BaseURL)
+	}
+
+	client.gatewayURL = server.URL + "/ex/jira/"
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.AccountID != "abc" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+}
+
+func TestOAuthClient_TokenError(t *testing.T) {
+	client := NewOAuthClient("https://mycompany.atlassian.net", staticTokens{err: errors.New("not logged in")}, "TEST")
+	if _, err := client.GetCurrentUser(); err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("expected the token error, got %v", err)
+	}
+}
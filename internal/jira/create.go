@@ -0,0 +1,85 @@
+package jira
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// IssueDraft describes an issue to create
+type IssueDraft struct {
+	ProjectKey string
+	IssueType  string // Issue type name (e.g., "Task")
+	Summary    string
+	ParentKey  string // Optional parent epic (e.g., "PROJ-100")
+}
+
+// CreateIssue creates an issue and returns it with the fields it was created with
+func (c *Client) CreateIssue(draft IssueDraft) (*Issue, error) {
+	log.Debug().
+		Str("project", draft.ProjectKey).
+		Str("type", draft.IssueType).
+		Str("parent", draft.ParentKey).
+		Msg("Creating issue")
+
+	// Epics are linked through a custom field on Jira Server, which differs between instances
+	if c.server && draft.ParentKey != "" {
+		return nil, fmt.Errorf("parent epics are not supported on Jira Server; set the epic link in Jira after creating the issue")
+	}
+
+	endpoint := c.apiURL("/issue")
+
+	fields := map[string]interface{}{
+		"project":   map[string]string{"key": draft.ProjectKey},
+		"issuetype": map[string]string{"name": draft.IssueType},
+		"summary":   draft.Summary,
+	}
+	if draft.ParentKey != "" {
+		fields["parent"] = map[string]string{"key": draft.ParentKey}
+	}
+
+	var created struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := c.doRequest("POST", endpoint, map[string]interface{}{"fields": fields}, &created); err != nil {
+		return nil, fmt.Errorf("failed to create issue in %s: %w", draft.ProjectKey, err)
+	}
+
+	log.Info().Str("key", created.Key).Msg("Issue created")
+
+	return &Issue{
+		ID:  created.ID,
+		Key: created.Key,
+		Fields: IssueFields{
+			Summary:   draft.Summary,
+			IssueType: IssueType{Name: draft.IssueType},
+		},
+	}, nil
+}
+
+// GetIssueTypes retrieves the names of the issue types that can be created in a project, without sub-tasks
+func (c *Client) GetIssueTypes(projectKey string) ([]string, error) {
+	log.Debug().Str("project", projectKey).Msg("Fetching issue types")
+
+	endpoint := c.apiURL("/project/%s", projectKey)
+
+	var project struct {
+		IssueTypes []struct {
+			Name    string `json:"name"`
+			Subtask bool   `json:"subtask"`
+		} `json:"issueTypes"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &project); err != nil {
+		return nil, fmt.Errorf("failed to fetch issue types for project %s: %w", projectKey, err)
+	}
+
+	var issueTypes []string
+	for _, issueType := range project.IssueTypes {
+		if !issueType.Subtask {
+			issueTypes = append(issueTypes, issueType.Name)
+		}
+	}
+
+	return issueTypes, nil
+}
@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Estimate adjustment modes, sent as Jira's adjustEstimate parameter
+const (
+	EstimateAuto   = "auto"   // Reduce the remaining estimate by the time logged (Jira's default)
+	EstimateLeave  = "leave"  // Keep the remaining estimate
+	EstimateNew    = "new"    // Set a new remaining estimate
+	EstimateManual = "manual" // Reduce the remaining estimate by a given amount
+)
+
+// EstimateAdjustment says how a worklog changes the issue's remaining estimate
+type EstimateAdjustment struct {
+	Mode    string // One of the Estimate* modes; empty means auto
+	Seconds int    // New remaining estimate for "new", reduction for "manual"
+}
+
+// IsAuto reports whether Jira's default adjustment applies
+func (a EstimateAdjustment) IsAuto() bool {
+	return a.Mode == "" || a.Mode == EstimateAuto
+}
+
+// String renders the adjustment like the --estimate flag: "auto", "leave", "new=2h" or "manual=30m"
+func (a EstimateAdjustment) String() string {
+	switch a.Mode {
+	case EstimateNew, EstimateManual:
+		return fmt.Sprintf("%s=%s", a.Mode, formatSeconds(a.Seconds))
+	case EstimateLeave:
+		return EstimateLeave
+	}
+	return EstimateAuto
+}
+
+// query returns the worklog query parameters for the adjustment
+func (a EstimateAdjustment) query() url.Values {
+	values := url.Values{}
+	switch a.Mode {
+	case EstimateLeave:
+		values.Set("adjustEstimate", EstimateLeave)
+	case EstimateNew:
+		values.Set("adjustEstimate", EstimateNew)
+		values.Set("newEstimate", formatSeconds(a.Seconds))
+	case EstimateManual:
+		values.Set("adjustEstimate", EstimateManual)
+		values.Set("reduceBy", formatSeconds(a.Seconds))
+	}
+	return values
+}
+
+// TimeTracking holds an issue's estimates and logged time (the timetracking field)
+type TimeTracking struct {
+	OriginalEstimateSeconds  int `json:"originalEstimateSeconds"`
+	RemainingEstimateSeconds int `json:"remainingEstimateSeconds"`
+	TimeSpentSeconds         int `json:"timeSpentSeconds"`
+}
+
+// HasEstimate reports whether the issue has an original or remaining estimate
+func (t *TimeTracking) HasEstimate() bool {
+	return t != nil && (t.OriginalEstimateSeconds > 0 || t.RemainingEstimateSeconds > 0)
+}
@@ -0,0 +1,71 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Transition is a workflow transition available on an issue
+type Transition struct {
+	ID   string      `json:"id"`
+	Name string      `json:"name"` // Transition name (e.g., "Start review")
+	To   IssueStatus `json:"to"`   // Status the issue moves to (e.g., "In Review")
+}
+
+// Label renders the transition for selection, like "Start review → In Review"
+func (t Transition) Label() string {
+	if t.To.Name == "" || strings.EqualFold(t.Name, t.To.Name) {
+		return t.Name
+	}
+	return fmt.Sprintf("%s → %s", t.Name, t.To.Name)
+}
+
+// GetTransitions retrieves the transitions the current user can make on an issue
+func (c *Client) GetTransitions(issueKey string) ([]Transition, error) {
+	log.Debug().Str("key", issueKey).Msg("Fetching transitions")
+
+	endpoint := c.apiURL("/issue/%s/transitions", issueKey)
+
+	var result struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch transitions for %s: %w", issueKey, err)
+	}
+
+	return result.Transitions, nil
+}
+
+// TransitionIssue moves an issue through a transition
+func (c *Client) TransitionIssue(issueKey, transitionID string) error {
+	log.Debug().Str("key", issueKey).Str("transition", transitionID).Msg("Transitioning issue")
+
+	endpoint := c.apiURL("/issue/%s/transitions", issueKey)
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+
+	if err := c.doRequest("POST", endpoint, payload, nil); err != nil {
+		return fmt.Errorf("failed to transition %s: %w", issueKey, err)
+	}
+
+	log.Info().Str("key", issueKey).Str("transition", transitionID).Msg("Issue transitioned")
+	return nil
+}
+
+// FindTransition returns the transition whose name or target status matches name, ignoring case
+func FindTransition(transitions []Transition, name string) (*Transition, bool) {
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].Name, name) {
+			return &transitions[i], true
+		}
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, name) {
+			return &transitions[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/slack"
+)
+
+// Feature is something a notifier backend can do
+type Feature int
+
+const (
+	// FeatureStatus sets and clears the user's status
+	FeatureStatus Feature = 1 << iota
+	// FeatureMessage posts a message to a channel
+	FeatureMessage
+	// FeatureThread posts messages as replies in a thread
+	FeatureThread
+	// FeatureUpdate edits a message that was posted earlier
+	FeatureUpdate
+)
+
+// ErrUnsupported is returned when a backend is asked to do something it can't
+var ErrUnsupported = errors.New("not supported by this notifier")
+
+// ErrNotConfigured is returned by New when the selected backend is missing settings
+var ErrNotConfigured = errors.New("notifier not configured")
+
+// Notifier sends break updates to a chat service
+type Notifier interface {
+	// Name is the service name shown to the user (e.g., "Slack")
+	Name() string
+	// Supports reports whether the backend can do feature
+	Supports(feature Feature) bool
+	// SetStatus sets the user's status until the given time (zero for no expiration)
+	SetStatus(text, emoji string, until time.Time) error
+	// ClearStatus clears the user's status
+	ClearStatus() error
+	// PostMessage posts a message written in Slack mrkdwn (*bold*), as a reply if threadID is set
+	// It returns the ID of the new message, or "" if the backend doesn't report one
+	PostMessage(text, threadID string) (string, error)
+	// UpdateMessage replaces the text of a message posted earlier
+	UpdateMessage(id, text string) error
+}
+
+// New returns the notifier selected by the notifier section of the config
+// Slack with a user token is used when no type is set
+func New(cfg *config.Config) (Notifier, error) {
+	switch cfg.Notifier.Type {
+	case "", config.NotifierSlack:
+		if cfg.Slack.UserToken == "" || cfg.Slack.ChannelID == "" {
+			return nil, fmt.Errorf("%w: set slack.user_token and slack.channel_id", ErrNotConfigured)
+		}
+		return NewSlack(slack.NewClient(cfg.Slack.UserToken, cfg.Slack.ChannelID)), nil
+	case config.NotifierSlackWebhook, config.NotifierMattermost, config.NotifierTeams:
+		if cfg.Notifier.WebhookURL == "" {
+			return nil, fmt.Errorf("%w: set notifier.webhook_url", ErrNotConfigured)
+		}
+		return NewWebhook(cfg.Notifier.Type, cfg.Notifier.WebhookURL), nil
+	}
+	return nil, fmt.Errorf("unknown notifier type: %s", cfg.Notifier.Type)
+}
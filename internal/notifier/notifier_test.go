@@ -0,0 +1,142 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          config.Config
+		expectName   string
+		expectStatus bool
+		expectErr    bool
+	}{
+		{
+			name:         "slack user token by default",
+			cfg:          config.Config{Slack: config.SlackConfig{UserToken: "xoxp-1", ChannelID: "C1"}},
+			expectName:   "Slack",
+			expectStatus: true,
+		},
+		{
+			name:      "slack without channel",
+			cfg:       config.Config{Slack: config.SlackConfig{UserToken: "xoxp-1"}},
+			expectErr: true,
+		},
+		{
+			name:       "slack webhook",
+			cfg:        config.Config{Notifier: config.NotifierConfig{Type: config.NotifierSlackWebhook, WebhookURL: "https://hooks.slack.com/x"}},
+			expectName: "Slack",
+		},
+		{
+			name:       "mattermost",
+			cfg:        config.Config{Notifier: config.NotifierConfig{Type: config.NotifierMattermost, WebhookURL: "https://mm.example.com/hooks/x"}},
+			expectName: "Mattermost",
+		},
+		{
+			name:       "teams",
+			cfg:        config.Config{Notifier: config.NotifierConfig{Type: config.NotifierTeams, WebhookURL: "https://example.webhook.office.com/x"}},
+			expectName: "Teams",
+		},
+		{
+			name:      "webhook without url",
+			cfg:       config.Config{Notifier: config.NotifierConfig{Type: config.NotifierTeams}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(&tt.cfg)
+			if tt.expectErr {
+				if !errors.Is(err, ErrNotConfigured) {
+					t.Fatalf("expected ErrNotConfigured, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n.Name() != tt.expectName {
+				t.Errorf("expected name %q, got %q", tt.expectName, n.Name())
+			}
+			if n.Supports(FeatureStatus) != tt.expectStatus {
+				t.Errorf("expected status support %v", tt.expectStatus)
+			}
+			if !n.Supports(FeatureMessage) {
+				t.Error("expected message support")
+			}
+		})
+	}
+}
+
+func TestWebhook_PostMessage(t *testing.T) {
+	tests := []struct {
+		kind   string
+		expect string
+	}{
+		{kind: config.NotifierSlackWebhook, expect: `{"text":"Back from *lunch break*"}`},
+		{kind: config.NotifierMattermost, expect: `{"text":"Back from **lunch break**"}`},
+		{kind: config.NotifierTeams, expect: `{"attachments":[{"content":{"$schema":"http://adaptivecards.io/schemas/adaptive-card.json","body":[{"text":"Back from **lunch break**","type":"TextBlock","wrap":true}],"type":"AdaptiveCard","version":"1.4"},"contentType":"application/vnd.microsoft.card.adaptive"}],"type":"message"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			var received json.RawMessage
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("expected JSON content type, got %q", r.Header.Get("Content-Type"))
+				}
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("failed to decode payload: %v", err)
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			if _, err := NewWebhook(tt.kind, server.URL).PostMessage("Back from *lunch break*", ""); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(received) != tt.expect {
+				t.Errorf("expected payload %s, got %s", tt.expect, received)
+			}
+		})
+	}
+}
+
+func TestWebhook_PostMessageError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no_service"))
+	}))
+	defer server.Close()
+
+	_, err := NewWebhook(config.NotifierSlackWebhook, server.URL).PostMessage("hello", "")
+	if err == nil || err.Error() != "Slack webhook error (status 404): no_service" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhook_StatusUnsupported(t *testing.T) {
+	w := NewWebhook(config.NotifierMattermost, "http://localhost")
+	if err := w.SetStatus("On lunch", ":pizza:", time.Now()); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+	if err := w.ClearStatus(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+	if err := w.UpdateMessage("1", "back"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+	if w.Supports(FeatureThread) || w.Supports(FeatureUpdate) {
+		t.Error("expected webhooks not to support threads or edits")
+	}
+}
@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"time"
+
+	"tasklog/internal/slack"
+)
+
+// Slack sends updates with a Slack user token: status, channel messages, threads and edits
+type Slack struct {
+	client *slack.Client
+}
+
+// NewSlack creates a notifier backed by a Slack API client
+func NewSlack(client *slack.Client) *Slack {
+	return &Slack{client: client}
+}
+
+// Name returns the service name
+func (s *Slack) Name() string {
+	return "Slack"
+}
+
+// Supports reports whether the backend can do feature
+func (s *Slack) Supports(feature Feature) bool {
+	return feature&(FeatureStatus|FeatureMessage|FeatureThread|FeatureUpdate) == feature
+}
+
+// SetStatus sets the Slack status until the given time (zero for no expiration)
+func (s *Slack) SetStatus(text, emoji string, until time.Time) error {
+	var expiration int64
+	if !until.IsZero() {
+		expiration = until.Unix()
+	}
+	return s.client.SetStatusUntil(text, emoji, expiration)
+}
+
+// ClearStatus clears the Slack status
+func (s *Slack) ClearStatus() error {
+	return s.client.ClearStatus()
+}
+
+// PostMessage posts a message to the configured channel and returns its timestamp
+func (s *Slack) PostMessage(text, threadID string) (string, error) {
+	return s.client.PostMessageInThread(text, threadID)
+}
+
+// UpdateMessage edits a message in the configured channel
+func (s *Slack) UpdateMessage(id, text string) error {
+	return s.client.UpdateMessage(id, text)
+}
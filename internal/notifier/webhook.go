@@ -0,0 +1,137 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"tasklog/internal/config"
+)
+
+// boldPattern matches Slack mrkdwn bold text (*bold*)
+var boldPattern = regexp.MustCompile(`\*([^*\n]+)\*`)
+
+// Webhook posts messages to an incoming webhook: Slack, Mattermost or Microsoft Teams
+// Incoming webhooks can't change the user's status, reply in threads or edit messages, so only messages are supported
+type Webhook struct {
+	kind       string // One of config.NotifierSlackWebhook, config.NotifierMattermost or config.NotifierTeams
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhook creates a notifier for an incoming webhook of the given kind
+func NewWebhook(kind, url string) *Webhook {
+	return &Webhook{
+		kind: kind,
+		url:  url,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Name returns the service name
+func (w *Webhook) Name() string {
+	switch w.kind {
+	case config.NotifierMattermost:
+		return "Mattermost"
+	case config.NotifierTeams:
+		return "Teams"
+	}
+	return "Slack"
+}
+
+// Supports reports whether the backend can do feature
+func (w *Webhook) Supports(feature Feature) bool {
+	return feature == FeatureMessage
+}
+
+// SetStatus is not supported by incoming webhooks
+func (w *Webhook) SetStatus(text, emoji string, until time.Time) error {
+	return ErrUnsupported
+}
+
+// ClearStatus is not supported by incoming webhooks
+func (w *Webhook) ClearStatus() error {
+	return ErrUnsupported
+}
+
+// UpdateMessage is not supported by incoming webhooks
+func (w *Webhook) UpdateMessage(id, text string) error {
+	return ErrUnsupported
+}
+
+// PostMessage posts a message to the webhook, converting the formatting for the target service
+// Webhooks don't return message IDs or support threads, so threadID is ignored and the ID is always ""
+func (w *Webhook) PostMessage(text, threadID string) (string, error) {
+	jsonData, err := json.Marshal(w.payload(text))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", w.url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call %s webhook: %w", w.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("%s webhook error (status %d): %s", w.Name(), resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	log.Debug().
+		Str("notifier", w.kind).
+		Str("text", text).
+		Msg("Message posted to webhook")
+
+	return "", nil
+}
+
+// payload builds the request body expected by the target service
+func (w *Webhook) payload(text string) interface{} {
+	switch w.kind {
+	case config.NotifierMattermost:
+		return map[string]interface{}{
+			"text": toMarkdown(text),
+		}
+	case config.NotifierTeams:
+		return map[string]interface{}{
+			"type": "message",
+			"attachments": []map[string]interface{}{
+				{
+					"contentType": "application/vnd.microsoft.card.adaptive",
+					"content": map[string]interface{}{
+						"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+						"type":    "AdaptiveCard",
+						"version": "1.4",
+						"body": []map[string]interface{}{
+							{"type": "TextBlock", "text": toMarkdown(text), "wrap": true},
+						},
+					},
+				},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"text": text,
+	}
+}
+
+// toMarkdown converts Slack mrkdwn bold (*bold*) to standard Markdown (**bold**)
+func toMarkdown(text string) string {
+	return boldPattern.ReplaceAllString(text, "**$1**")
+}
@@ -0,0 +1,99 @@
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Authorization endpoints; variables so tests can point them at a local server
+var (
+	atlassianAuthURL      = "https://auth.atlassian.com/authorize"
+	atlassianTokenURL     = "https://auth.atlassian.com/oauth/token"
+	atlassianResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+	tempoTokenURL         = "https://api.tempo.io/oauth/token/"
+)
+
+// AtlassianScopes are the Jira scopes tasklog needs; offline_access grants the refresh token
+var AtlassianScopes = []string{"read:jira-work", "write:jira-work", "read:jira-user", "offline_access"}
+
+// Atlassian returns the provider for an Atlassian OAuth 2.0 (3LO) app
+func Atlassian(clientID, clientSecret, redirectURI string) Provider {
+	return Provider{
+		Name:         "Jira",
+		AuthURL:      atlassianAuthURL,
+		TokenURL:     atlassianTokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  redirectURI,
+		Scopes:       AtlassianScopes,
+		AuthParams: map[string]string{
+			"audience": "api.atlassian.com",
+			"prompt":   "consent",
+		},
+	}
+}
+
+// Tempo returns the provider for a Tempo OAuth 2.0 app; Tempo authorizes through the Jira site
+func Tempo(siteURL, clientID, clientSecret, redirectURI string) Provider {
+	return Provider{
+		Name:         "Tempo",
+		AuthURL:      strings.TrimSuffix(siteURL, "/") + "/plugins/servlet/ac/io.tempo.jira/oauth-authorize/",
+		TokenURL:     tempoTokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  redirectURI,
+		AuthParams: map[string]string{
+			"access_type": "tenant_user",
+		},
+	}
+}
+
+// AtlassianCloudID finds the cloud ID of the Jira site at siteURL among the sites the token can access
+func AtlassianCloudID(accessToken, siteURL string) (string, error) {
+	req, err := http.NewRequest("GET", atlassianResourcesURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch accessible sites: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to fetch accessible sites: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var sites []struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(body, &sites); err != nil {
+		return "", fmt.Errorf("failed to parse accessible sites: %w", err)
+	}
+
+	want := normalizeSite(siteURL)
+	urls := make([]string, 0, len(sites))
+	for _, site := range sites {
+		if normalizeSite(site.URL) == want {
+			return site.ID, nil
+		}
+		urls = append(urls, site.URL)
+	}
+	return "", fmt.Errorf("the app was not granted access to %s (granted: %s)", siteURL, strings.Join(urls, ", "))
+}
+
+// normalizeSite makes site URLs comparable regardless of case and trailing slash
+func normalizeSite(siteURL string) string {
+	return strings.ToLower(strings.TrimSuffix(siteURL, "/"))
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package oauth
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockFile blocks until it holds an exclusive lock on f
+func lockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_EX) //nolint:gosec // G115: file descriptors fit in int
+}
+
+// unlockFile releases the lock taken by lockFile
+func unlockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN) //nolint:gosec // G115: file descriptors fit in int
+}
@@ -0,0 +1,17 @@
+package oauth
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile blocks until it holds an exclusive lock on f
+func lockFile(f *os.File) error {
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, new(windows.Overlapped))
+}
+
+// unlockFile releases the lock taken by lockFile
+func unlockFile(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, new(windows.Overlapped))
+}
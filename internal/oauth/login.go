@@ -0,0 +1,97 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// loginTimeout is how long Login waits for the user to authorize in the browser
+const loginTimeout = 5 * time.Minute
+
+// Login runs the authorization code flow with PKCE. It listens on the loopback
+// redirect URI, calls open with the authorization URL and exchanges the code
+// the browser is redirected back with
+func Login(p Provider, open func(authURL string)) (*Token, error) {
+	redirect, err := url.Parse(p.RedirectURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URI %q: %w", p.RedirectURI, err)
+	}
+
+	state, err := randomString(24)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString(48)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", redirect.Host, err)
+	}
+
+	// Only the first callback counts; later ones (e.g., a reload) must not block
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	fail := func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirect.Path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("state") != state:
+			http.Error(w, "Login failed: unexpected state. Please try again.", http.StatusBadRequest)
+			fail(errors.New("authorization response has an unexpected state"))
+		case query.Get("error") != "":
+			http.Error(w, "Login failed: "+query.Get("error")+". You can close this window.", http.StatusBadRequest)
+			fail(fmt.Errorf("authorization was denied: %s %s", query.Get("error"), query.Get("error_description")))
+		case query.Get("code") == "":
+			http.Error(w, "Login failed: no authorization code. Please try again.", http.StatusBadRequest)
+			fail(errors.New("authorization response has no code"))
+		default:
+			fmt.Fprintf(w, "Logged in to %s. You can close this window and return to tasklog.\n", p.Name)
+			select {
+			case codes <- query.Get("code"):
+			default:
+			}
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fail(err)
+		}
+	}()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Debug().Err(err).Msg("Failed to stop OAuth callback server")
+		}
+	}()
+
+	open(p.AuthCodeURL(state, codeChallenge(verifier)))
+
+	select {
+	case code := <-codes:
+		return p.Exchange(code, verifier)
+	case err := <-errs:
+		return nil, err
+	case <-time.After(loginTimeout):
+		return nil, fmt.Errorf("timed out after %s waiting for authorization", loginTimeout)
+	}
+}
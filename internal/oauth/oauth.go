@@ -0,0 +1,168 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// expiryMargin refreshes tokens a little before they expire, so requests don't race the expiry
+const expiryMargin = time.Minute
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Provider describes an OAuth 2.0 app and the endpoints of its authorization server
+type Provider struct {
+	Name         string // Shown to the user (e.g., "Jira")
+	AuthURL      string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string            // Loopback callback registered with the app (e.g., "http://localhost:8765/callback")
+	Scopes       []string          // Requested scopes, if the server uses them
+	AuthParams   map[string]string // Extra authorization parameters (e.g., "audience")
+}
+
+// Token is an access token with the refresh token needed to renew it
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry"`
+	CloudID      string    `json:"cloud_id,omitempty"` // Atlassian site the token was granted for (Jira only)
+}
+
+// Valid reports whether the access token can still be used at now
+func (t *Token) Valid(now time.Time) bool {
+	return t.AccessToken != "" && (t.Expiry.IsZero() || now.Add(expiryMargin).Before(t.Expiry))
+}
+
+// AuthCodeURL builds the URL the user opens to authorize the app
+func (p Provider) AuthCodeURL(state, challenge string) string {
+	query := url.Values{}
+	query.Set("client_id", p.ClientID)
+	query.Set("redirect_uri", p.RedirectURI)
+	query.Set("response_type", "code")
+	query.Set("state", state)
+	query.Set("code_challenge", challenge)
+	query.Set("code_challenge_method", "S256")
+	if len(p.Scopes) > 0 {
+		query.Set("scope", strings.Join(p.Scopes, " "))
+	}
+	for key, value := range p.AuthParams {
+		query.Set(key, value)
+	}
+
+	separator := "?"
+	if strings.Contains(p.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.AuthURL + separator + query.Encode()
+}
+
+// Exchange trades an authorization code for a token
+func (p Provider) Exchange(code, verifier string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("code_verifier", verifier)
+
+	token, err := p.requestToken(form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	return token, nil
+}
+
+// Refresh renews an access token; servers that rotate refresh tokens return a new one
+func (p Provider) Refresh(refreshToken string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	token, err := p.requestToken(form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh %s token: %w", p.Name, err)
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// requestToken posts a token request with the client credentials
+func (p Provider) requestToken(form url.Values) (*Token, error) {
+	form.Set("client_id", p.ClientID)
+	if p.ClientSecret != "" {
+		form.Set("client_secret", p.ClientSecret)
+	}
+	form.Set("redirect_uri", p.RedirectURI)
+
+	log.Debug().Str("provider", p.Name).Str("grant_type", form.Get("grant_type")).Msg("Requesting OAuth token")
+
+	req, err := http.NewRequest("POST", p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access token")
+	}
+
+	token := &Token{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	}
+	if result.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// randomString returns n random bytes, base64url encoded
+func randomString(n int) (string, error) {
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		return "", fmt.Errorf("failed to generate random data: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// codeChallenge derives the S256 PKCE challenge from a verifier
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
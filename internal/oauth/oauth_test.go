@@ -0,0 +1,349 @@
+package oauth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// tokenServer answers token requests; rotate controls whether refreshes return a new refresh token
+func tokenServer(t *testing.T, rotate bool, requests *[]url.Values) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		*requests = append(*requests, r.PostForm)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			fmt.Fprintf(w, `{"access_token":"access-%s","refresh_token":"refresh-1","expires_in":3600}`, r.PostForm.Get("code"))
+		case "refresh_token":
+			if rotate {
+				w.Write([]byte(`{"access_token":"access-2","refresh_token":"refresh-2","expires_in":3600}`))
+			} else {
+				w.Write([]byte(`{"access_token":"access-2","expires_in":3600}`))
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	provider := Atlassian("client", "secret", "http://localhost:8765/callback")
+	authURL, err := url.Parse(provider.AuthCodeURL("state-1", codeChallenge("verifier")))
+	if err != nil {
+		t.Fatalf("invalid URL: %v", err)
+	}
+
+	query := authURL.Query()
+	expected := map[string]string{
+		"client_id":             "client",
+		"redirect_uri":          "http://localhost:8765/callback",
+		"response_type":         "code",
+		"state":                 "state-1",
+		"code_challenge":        codeChallenge("verifier"),
+		"code_challenge_method": "S256",
+		"audience":              "api.atlassian.com",
+		"scope":                 "read:jira-work write:jira-work read:jira-user offline_access",
+	}
+	for key, want := range expected {
+		if got := query.Get(key); got != want {
+			t.Errorf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if query.Get("client_secret") != "" {
+		t.Error("client secret must not be sent to the browser")
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	if got := codeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("unexpected challenge %q", got)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	var requests []url.Values
+	server := tokenServer(t, true, &requests)
+	defer server.Close()
+
+	// Reserve a free port for the callback listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr())
+	listener.Close()
+
+	provider := Provider{Name: "Jira", AuthURL: "https://auth.example.com/authorize", TokenURL: server.URL, ClientID: "client", RedirectURI: redirectURI}
+
+	// Play the browser: follow the authorization URL back to the callback
+	open := func(authURL string) {
+		parsed, err := url.Parse(authURL)
+		if err != nil {
+			t.Errorf("invalid authorization URL: %v", err)
+			return
+		}
+		callback := fmt.Sprintf("%s?code=abc&state=%s", redirectURI, url.QueryEscape(parsed.Query().Get("state")))
+		go func() {
+			resp, err := http.Get(callback)
+			if err != nil {
+				t.Errorf("callback failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+
+	token, err := Login(provider, open)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "access-abc" || token.RefreshToken != "refresh-1" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+	if len(requests) != 1 || requests[0].Get("code_verifier") == "" || requests[0].Get("redirect_uri") != redirectURI {
+		t.Errorf("unexpected token request: %v", requests)
+	}
+}
+
+func TestLogin_StateMismatch(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr())
+	listener.Close()
+
+	provider := Provider{Name: "Jira", AuthURL: "https://auth.example.com/authorize", TokenURL: "http://127.0.0.1:1", RedirectURI: redirectURI}
+	open := func(string) {
+		go func() {
+			resp, err := http.Get(redirectURI + "?code=abc&state=forged")
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+
+	if _, err := Login(provider, open); err == nil || !strings.Contains(err.Error(), "state") {
+		t.Errorf("expected a state error, got %v", err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "tokens.json"))
+
+	if token, err := store.Load("jira"); err != nil || token != nil {
+		t.Fatalf("expected no token, got %+v, %v", token, err)
+	}
+
+	if err := store.Save("jira", &Token{AccessToken: "a", RefreshToken: "r", CloudID: "cloud"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Save("tempo", &Token{AccessToken: "t"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token, err := store.Load("jira")
+	if err != nil || token == nil || token.RefreshToken != "r" || token.CloudID != "cloud" {
+		t.Fatalf("unexpected token: %+v, %v", token, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(store.Path())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+		}
+	}
+
+	if deleted, err := store.Delete("jira"); err != nil || !deleted {
+		t.Fatalf("expected jira token to be deleted, got %v, %v", deleted, err)
+	}
+	if deleted, _ := store.Delete("jira"); deleted {
+		t.Error("expected nothing to delete the second time")
+	}
+	if token, _ := store.Load("tempo"); token == nil {
+		t.Error("expected tempo token to be kept")
+	}
+}
+
+func TestStore_RejectsOpenPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no Unix permissions on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil { //nolint:gosec // G306: the test needs a readable file
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if _, err := NewStore(path).Load("jira"); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+		t.Errorf("expected a permission error, got %v", err)
+	}
+}
+
+func TestTokenSource_RefreshesExpiredToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		rotate      bool
+		wantRefresh string
+	}{
+		{"rotating refresh token", true, "refresh-2"},
+		{"reused refresh token", false, "refresh-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []url.Values
+			server := tokenServer(t, tt.rotate, &requests)
+			defer server.Close()
+
+			store := NewStore(filepath.Join(t.TempDir(), "tokens.json"))
+			expired := &Token{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(-time.Minute), CloudID: "cloud"}
+			if err := store.Save("jira", expired); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			source := NewTokenSource(Provider{Name: "Jira", TokenURL: server.URL, ClientID: "client"}, store, "jira")
+			for i := 0; i < 2; i++ {
+				access, err := source.AccessToken()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if access != "access-2" {
+					t.Errorf("expected refreshed access token, got %q", access)
+				}
+			}
+			if len(requests) != 1 {
+				t.Errorf("expected one refresh, got %d", len(requests))
+			}
+
+			saved, err := store.Load("jira")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if saved.RefreshToken != tt.wantRefresh || saved.CloudID != "cloud" {
+				t.Errorf("unexpected saved token: %+v", saved)
+			}
+		})
+	}
+}
+
+func TestTokenSource_ReloadsRotatedToken(t *testing.T) {
+	var requests []url.Values
+	server := tokenServer(t, true, &requests)
+	defer server.Close()
+
+	store := NewStore(filepath.Join(t.TempDir(), "tokens.json"))
+	provider := Provider{Name: "Jira", TokenURL: server.URL, ClientID: "client"}
+	if err := store.Save("jira", &Token{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A long-running process loads the token while it is still valid
+	source := NewTokenSource(provider, store, "jira")
+	if _, err := source.AccessToken(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Meanwhile another process refreshes and rotates the refresh token, which expires too
+	if err := store.Save("jira", &Token{AccessToken: "access-other", RefreshToken: "refresh-other", Expiry: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source.token.Expiry = time.Now().Add(-time.Minute)
+
+	access, err := source.AccessToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if access != "access-2" {
+		t.Errorf("expected refreshed access token, got %q", access)
+	}
+	if len(requests) != 1 || requests[0].Get("refresh_token") != "refresh-other" {
+		t.Errorf("expected a refresh with the stored refresh token, got %v", requests)
+	}
+
+	// A token another process already refreshed is used as is
+	if err := store.Save("jira", &Token{AccessToken: "access-3", RefreshToken: "refresh-3", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source.token.Expiry = time.Now().Add(-time.Minute)
+
+	if access, err := source.AccessToken(); err != nil || access != "access-3" {
+		t.Errorf("expected the stored token, got %q (%v)", access, err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected no further refresh, got %d", len(requests))
+	}
+}
+
+func TestTokenSource_NotLoggedIn(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "tokens.json"))
+	source := NewTokenSource(Provider{Name: "Jira"}, store, "jira")
+
+	if _, err := source.AccessToken(); err == nil || !strings.Contains(err.Error(), "tasklog login") {
+		t.Errorf("expected a not logged in error, got %v", err)
+	}
+}
+
+func TestTokenSource_CloudID(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "tokens.json"))
+	source := NewTokenSource(Provider{Name: "Jira"}, store, "jira")
+
+	if err := store.Save("jira", &Token{AccessToken: "access-1", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := source.CloudID(); err == nil || !strings.Contains(err.Error(), "tasklog login") {
+		t.Errorf("expected a not logged in error without a cloud ID, got %v", err)
+	}
+
+	source = NewTokenSource(Provider{Name: "Jira"}, store, "jira")
+	if err := store.Save("jira", &Token{AccessToken: "access-1", Expiry: time.Now().Add(time.Hour), CloudID: "cloud"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cloudID, err := source.CloudID(); err != nil || cloudID != "cloud" {
+		t.Errorf("expected the stored cloud ID, got %q (%v)", cloudID, err)
+	}
+}
+
+func TestAtlassianCloudID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"other-id","url":"https://other.atlassian.net"},{"id":"site-id","url":"https://mycompany.atlassian.net"}]`))
+	}))
+	defer server.Close()
+
+	original := atlassianResourcesURL
+	atlassianResourcesURL = server.URL
+	defer func() { atlassianResourcesURL = original }()
+
+	cloudID, err := AtlassianCloudID("access", "https://MyCompany.atlassian.net/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cloudID != "site-id" {
+		t.Errorf("expected site-id, got %q", cloudID)
+	}
+
+	if _, err := AtlassianCloudID("access", "https://unknown.atlassian.net"); err == nil {
+		t.Error("expected an error for a site the app can't access")
+	}
+}
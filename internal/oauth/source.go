@@ -0,0 +1,95 @@
+package oauth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNotLoggedIn is returned when no token was stored by 'tasklog login'
+var ErrNotLoggedIn = errors.New("not logged in; run 'tasklog login'")
+
+// TokenSource hands out access tokens from a store, refreshing and saving them when they expire
+type TokenSource struct {
+	provider Provider
+	store    *Store
+	name     string
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewTokenSource creates a token source for the named token in store
+func NewTokenSource(provider Provider, store *Store, name string) *TokenSource {
+	return &TokenSource{provider: provider, store: store, name: name}
+}
+
+// Token returns the current token, refreshing it first if it has expired
+func (s *TokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		token, err := s.store.Load(s.name)
+		if err != nil {
+			return nil, err
+		}
+		if token == nil {
+			return nil, fmt.Errorf("%s: %w", s.provider.Name, ErrNotLoggedIn)
+		}
+		s.token = token
+	}
+
+	if s.token.Valid(time.Now()) {
+		return s.token, nil
+	}
+
+	// Another tasklog process may have refreshed, and so rotated, the refresh token since it was
+	// loaded; re-read it under the lock and save the new one before anyone else can refresh
+	token, err := s.store.Update(s.name, func(current *Token) (*Token, error) {
+		if current == nil {
+			return nil, fmt.Errorf("%s: %w", s.provider.Name, ErrNotLoggedIn)
+		}
+		if current.Valid(time.Now()) {
+			return current, nil
+		}
+		if current.RefreshToken == "" {
+			return nil, fmt.Errorf("%s token expired: %w", s.provider.Name, ErrNotLoggedIn)
+		}
+
+		refreshed, err := s.provider.Refresh(current.RefreshToken)
+		if err != nil {
+			return nil, fmt.Errorf("%w; run 'tasklog login' if access was revoked", err)
+		}
+		refreshed.CloudID = current.CloudID
+		return refreshed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.token = token
+	return s.token, nil
+}
+
+// AccessToken returns a valid access token
+func (s *TokenSource) AccessToken() (string, error) {
+	token, err := s.Token()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// CloudID returns the Atlassian site the token was issued for, as stored by 'tasklog login'
+func (s *TokenSource) CloudID() (string, error) {
+	token, err := s.Token()
+	if err != nil {
+		return "", err
+	}
+	if token.CloudID == "" {
+		return "", fmt.Errorf("%s: no site stored with the token: %w", s.provider.Name, ErrNotLoggedIn)
+	}
+	return token.CloudID, nil
+}
@@ -0,0 +1,165 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Store keeps tokens by name (e.g., "jira", "tempo") in a JSON file only the owner can read
+type Store struct {
+	path string
+}
+
+// NewStore creates a token store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file the tokens are kept in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load returns the named token, or nil when there is none
+func (s *Store) Load(name string) (*Token, error) {
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return tokens[name], nil
+}
+
+// Save stores the named token, replacing any previous one
+func (s *Store) Save(name string, token *Token) error {
+	_, err := s.Update(name, func(*Token) (*Token, error) {
+		return token, nil
+	})
+	return err
+}
+
+// Delete removes the named token; it reports whether there was one
+func (s *Store) Delete(name string) (bool, error) {
+	deleted := false
+	err := s.locked(func() error {
+		tokens, err := s.read()
+		if err != nil {
+			return err
+		}
+		if _, ok := tokens[name]; !ok {
+			return nil
+		}
+		delete(tokens, name)
+		deleted = true
+		return s.write(tokens)
+	})
+	return deleted, err
+}
+
+// Update replaces the named token with what update returns for the stored one, holding the file lock
+// throughout, so concurrent tasklog processes don't lose each other's changes. Returning the same
+// token leaves the file untouched
+func (s *Store) Update(name string, update func(current *Token) (*Token, error)) (*Token, error) {
+	var result *Token
+	err := s.locked(func() error {
+		tokens, err := s.read()
+		if err != nil {
+			return err
+		}
+
+		current := tokens[name]
+		result, err = update(current)
+		if err != nil {
+			return err
+		}
+		if result == current {
+			return nil
+		}
+
+		tokens[name] = result
+		return s.write(tokens)
+	})
+	return result, err
+}
+
+// locked runs fn while holding an exclusive lock on a file next to the token file
+func (s *Store) locked(fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+
+	lock, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open token lock: %w", err)
+	}
+	defer lock.Close()
+
+	if err := lockFile(lock); err != nil {
+		return fmt.Errorf("failed to lock token file: %w", err)
+	}
+	defer func() {
+		_ = unlockFile(lock)
+	}()
+
+	return fn()
+}
+
+// read loads all tokens, refusing files that other users could read
+func (s *Store) read() (map[string]*Token, error) {
+	tokens := make(map[string]*Token)
+
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	// Windows doesn't have Unix permission bits
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("token file %s is accessible by other users; run: chmod 600 %s", s.path, s.path)
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse token file %s: %w", s.path, err)
+	}
+	return tokens, nil
+}
+
+// write replaces the token file atomically, readable by the owner only
+func (s *Store) write(tokens map[string]*Token) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode tokens: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".tokens-*")
+	if err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to protect token file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"tasklog/internal/config"
+	"tasklog/internal/timeparse"
+)
+
+// Worklog is what the policy is checked against
+type Worklog struct {
+	IssueKey  string
+	Project   string // Jira project key (e.g., "PROJ"), see ProjectKey
+	IssueType string // Jira issue type (e.g., "Bug"); only needed when NeedsIssueType is true
+	Label     string
+	Comment   string
+	Seconds   int
+}
+
+// Policy enforces the worklog rules from the policy config
+type Policy struct {
+	cfg             config.PolicyConfig
+	maxEntrySeconds int
+}
+
+// New creates a policy from the policy config
+func New(cfg config.PolicyConfig) *Policy {
+	p := &Policy{cfg: cfg}
+	if cfg.MaxEntry != "" {
+		p.maxEntrySeconds, _ = timeparse.Parse(cfg.MaxEntry)
+	}
+	return p
+}
+
+// NeedsIssueType reports whether checks depend on the issue type, which may need a Jira lookup
+func (p *Policy) NeedsIssueType() bool {
+	return len(p.cfg.RequireCommentTypes) > 0
+}
+
+// Check returns an explanation for every rule the worklog breaks
+func (p *Policy) Check(w Worklog) []string {
+	var violations []string
+
+	comment := strings.TrimSpace(w.Comment)
+	if comment == "" {
+		if p.cfg.RequireComment {
+			violations = append(violations, "a comment is required for every worklog (policy.require_comment)")
+		} else if requiredType := p.commentRequiredType(w.IssueType); requiredType != "" {
+			violations = append(violations, fmt.Sprintf("a comment is required for %s issues, and %s is a %s (policy.require_comment_types)", requiredType, w.IssueKey, w.IssueType))
+		}
+	} else if length := utf8.RuneCountInString(comment); length < p.cfg.MinCommentLength {
+		violations = append(violations, fmt.Sprintf("the comment must be at least %d characters, it has %d (policy.min_comment_length)", p.cfg.MinCommentLength, length))
+	}
+
+	if p.maxEntrySeconds > 0 && w.Seconds > p.maxEntrySeconds {
+		violations = append(violations, fmt.Sprintf("%s is longer than the %s allowed for one worklog; split it into several (policy.max_entry)",
+			timeparse.Format(w.Seconds), timeparse.Format(p.maxEntrySeconds)))
+	}
+
+	project := strings.ToUpper(w.Project)
+	for _, lp := range p.cfg.LabelProjects {
+		if !strings.EqualFold(lp.Label, w.Label) || containsFold(lp.Projects, project) {
+			continue
+		}
+		violations = append(violations, fmt.Sprintf("label %q is only allowed on %s, not on %s (policy.label_projects)", w.Label, strings.Join(lp.Projects, ", "), project))
+	}
+
+	return violations
+}
+
+// commentRequiredType returns the configured issue type matching issueType, if its worklogs need a comment
+func (p *Policy) commentRequiredType(issueType string) string {
+	for _, t := range p.cfg.RequireCommentTypes {
+		if issueType != "" && strings.EqualFold(t, issueType) {
+			return t
+		}
+	}
+	return ""
+}
+
+// ProjectKey returns the project part of an issue key ("PROJ" for "PROJ-123")
+func ProjectKey(issueKey string) string {
+	if i := strings.LastIndex(issueKey, "-"); i > 0 {
+		return strings.ToUpper(issueKey[:i])
+	}
+	return strings.ToUpper(issueKey)
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"tasklog/internal/config"
+)
+
+func TestCheck(t *testing.T) {
+	cfg := config.PolicyConfig{
+		RequireCommentTypes: []string{"Bug"},
+		MinCommentLength:    10,
+		MaxEntry:            "8h",
+		LabelProjects: []config.LabelProject{
+			{Label: "billable", Projects: []string{"ACME", "Globex"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.PolicyConfig
+		worklog  Worklog
+		expected []string // Expected violation fragments, in order
+	}{
+		{
+			name:    "valid worklog",
+			cfg:     cfg,
+			worklog: Worklog{IssueKey: "ACME-1", Project: "ACME", IssueType: "Bug", Label: "billable", Comment: "Fixed the crash on save", Seconds: 3600},
+		},
+		{
+			name:    "comment not required for other types",
+			cfg:     cfg,
+			worklog: Worklog{IssueKey: "PROJ-1", Project: "PROJ", IssueType: "Story", Seconds: 3600},
+		},
+		{
+			name:     "comment required for bugs",
+			cfg:      cfg,
+			worklog:  Worklog{IssueKey: "PROJ-1", Project: "PROJ", IssueType: "bug", Seconds: 3600},
+			expected: []string{"a comment is required for Bug issues, and PROJ-1 is a bug"},
+		},
+		{
+			name:     "comment required for every worklog",
+			cfg:      config.PolicyConfig{RequireComment: true},
+			worklog:  Worklog{IssueKey: "PROJ-1", Project: "PROJ", Comment: "  ", Seconds: 3600},
+			expected: []string{"a comment is required for every worklog"},
+		},
+		{
+			name:     "short comment",
+			cfg:      cfg,
+			worklog:  Worklog{IssueKey: "PROJ-1", Project: "PROJ", Comment: "fix", Seconds: 3600},
+			expected: []string{"at least 10 characters, it has 3"},
+		},
+		{
+			name:     "entry too long",
+			cfg:      cfg,
+			worklog:  Worklog{IssueKey: "PROJ-1", Project: "PROJ", Seconds: 9 * 3600},
+			expected: []string{"9h is longer than the 8h allowed"},
+		},
+		{
+			name:     "label on another project",
+			cfg:      cfg,
+			worklog:  Worklog{IssueKey: "PROJ-1", Project: "PROJ", Label: "Billable", Seconds: 3600},
+			expected: []string{`label "Billable" is only allowed on ACME, Globex, not on PROJ`},
+		},
+		{
+			name:    "label project matches case insensitively",
+			cfg:     cfg,
+			worklog: Worklog{IssueKey: "GLOBEX-4", Project: "GLOBEX", Label: "billable", Seconds: 3600},
+		},
+		{
+			name:    "new issue in an allowed project",
+			cfg:     cfg,
+			worklog: Worklog{IssueKey: "new ACME Task", Project: "ACME", Label: "billable", Seconds: 3600},
+		},
+		{
+			name:     "new issue in another project",
+			cfg:      cfg,
+			worklog:  Worklog{IssueKey: "new PROJ Task", Project: "PROJ", Label: "billable", Seconds: 3600},
+			expected: []string{`only allowed on ACME, Globex, not on PROJ`},
+		},
+		{
+			name:     "several violations",
+			cfg:      cfg,
+			worklog:  Worklog{IssueKey: "PROJ-1", Project: "PROJ", IssueType: "Bug", Label: "billable", Seconds: 10 * 3600},
+			expected: []string{"a comment is required", "longer than", "only allowed on"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := New(tt.cfg).Check(tt.worklog)
+			if len(violations) != len(tt.expected) {
+				t.Fatalf("got %d violations %q, want %d", len(violations), violations, len(tt.expected))
+			}
+			for i, fragment := range tt.expected {
+				if !strings.Contains(violations[i], fragment) {
+					t.Errorf("violation %d = %q, want it to contain %q", i, violations[i], fragment)
+				}
+			}
+		})
+	}
+}
+
+func TestNeedsIssueType(t *testing.T) {
+	if New(config.PolicyConfig{MaxEntry: "8h"}).NeedsIssueType() {
+		t.Error("expected no issue type lookup without require_comment_types")
+	}
+	if !New(config.PolicyConfig{RequireCommentTypes: []string{"Bug"}}).NeedsIssueType() {
+		t.Error("expected an issue type lookup with require_comment_types")
+	}
+}
+
+func TestProjectKey(t *testing.T) {
+	tests := map[string]string{
+		"PROJ-123":   "PROJ",
+		"acme-7":     "ACME",
+		"MY-TEAM-12": "MY-TEAM",
+		"NOKEY":      "NOKEY",
+	}
+	for key, expected := range tests {
+		if got := ProjectKey(key); got != expected {
+			t.Errorf("ProjectKey(%q) = %q, want %q", key, got, expected)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package pomodoro
+
+import (
+	"time"
+
+	"tasklog/internal/config"
+)
+
+// Phase is a step of a pomodoro cycle
+type Phase int
+
+const (
+	PhaseWork Phase = iota
+	PhaseShortBreak
+	PhaseLongBreak
+)
+
+// String returns the phase name shown to the user
+func (p Phase) String() string {
+	switch p {
+	case PhaseWork:
+		return "work"
+	case PhaseShortBreak:
+		return "short break"
+	case PhaseLongBreak:
+		return "long break"
+	}
+	return "unknown"
+}
+
+// Plan holds the interval lengths of a pomodoro cycle
+type Plan struct {
+	Work           time.Duration
+	ShortBreak     time.Duration
+	LongBreak      time.Duration
+	LongBreakEvery int // Work intervals before a long break
+}
+
+// NewPlan builds a plan from the pomodoro config (lengths in minutes)
+func NewPlan(cfg config.PomodoroConfig) Plan {
+	return Plan{
+		Work:           time.Duration(cfg.Work) * time.Minute,
+		ShortBreak:     time.Duration(cfg.ShortBreak) * time.Minute,
+		LongBreak:      time.Duration(cfg.LongBreak) * time.Minute,
+		LongBreakEvery: cfg.LongBreakEvery,
+	}
+}
+
+// BreakAfter returns the break that follows the given number of completed work intervals
+func (p Plan) BreakAfter(completed int) Phase {
+	if p.LongBreakEvery > 0 && completed > 0 && completed%p.LongBreakEvery == 0 {
+		return PhaseLongBreak
+	}
+	return PhaseShortBreak
+}
+
+// Duration returns how long a phase lasts
+func (p Plan) Duration(phase Phase) time.Duration {
+	switch phase {
+	case PhaseShortBreak:
+		return p.ShortBreak
+	case PhaseLongBreak:
+		return p.LongBreak
+	}
+	return p.Work
+}
+
+// Session tracks the work done in a pomodoro run
+type Session struct {
+	Started   time.Time // When the first work interval started
+	Completed int       // Completed work intervals
+	Worked    time.Duration
+}
+
+// CompleteWork records a finished work interval that started at the given time
+func (s *Session) CompleteWork(start time.Time, length time.Duration) {
+	if s.Completed == 0 {
+		s.Started = start
+	}
+	s.Completed++
+	s.Worked += length
+}
+
+// WorkedSeconds returns the total time of completed work intervals in seconds
+func (s *Session) WorkedSeconds() int {
+	return int(s.Worked.Seconds())
+}
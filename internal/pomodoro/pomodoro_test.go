@@ -0,0 +1,56 @@
+package pomodoro
+
+import (
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+)
+
+func TestPlan_BreakAfter(t *testing.T) {
+	plan := NewPlan(config.PomodoroConfig{Work: 25, ShortBreak: 5, LongBreak: 15, LongBreakEvery: 4})
+
+	expected := []Phase{PhaseShortBreak, PhaseShortBreak, PhaseShortBreak, PhaseLongBreak, PhaseShortBreak, PhaseShortBreak, PhaseShortBreak, PhaseLongBreak}
+	for i, phase := range expected {
+		if got := plan.BreakAfter(i + 1); got != phase {
+			t.Errorf("after %d intervals: expected %s, got %s", i+1, phase, got)
+		}
+	}
+}
+
+func TestPlan_Duration(t *testing.T) {
+	plan := NewPlan(config.PomodoroConfig{Work: 25, ShortBreak: 5, LongBreak: 15, LongBreakEvery: 4})
+
+	tests := []struct {
+		phase    Phase
+		expected time.Duration
+	}{
+		{PhaseWork, 25 * time.Minute},
+		{PhaseShortBreak, 5 * time.Minute},
+		{PhaseLongBreak, 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := plan.Duration(tt.phase); got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.phase, tt.expected, got)
+		}
+	}
+}
+
+func TestSession_CompleteWork(t *testing.T) {
+	first := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	var session Session
+	session.CompleteWork(first, 25*time.Minute)
+	session.CompleteWork(first.Add(30*time.Minute), 25*time.Minute)
+
+	if session.Completed != 2 {
+		t.Errorf("expected 2 completed intervals, got %d", session.Completed)
+	}
+	if !session.Started.Equal(first) {
+		t.Errorf("expected session to start at the first interval, got %s", session.Started)
+	}
+	if session.WorkedSeconds() != 50*60 {
+		t.Errorf("expected 50m worked, got %ds", session.WorkedSeconds())
+	}
+}
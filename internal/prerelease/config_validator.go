@@ -139,6 +139,7 @@ func FormatIssues(issues []ConfigIssue) string {
 			sb.WriteString("\n")
 		}
 	}
-	sb.WriteString("\n✓  Run tasklog config compare to see detailed differences\n\n")
+	sb.WriteString("\n✓  Run tasklog config migrate to fix these automatically\n")
+	sb.WriteString("✓  Run tasklog config compare to see detailed differences\n\n")
 	return sb.String()
 }
@@ -0,0 +1,168 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"tasklog/internal/slack"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+// workDaysPerWeek is used to derive the weekly target from the daily target
+const workDaysPerWeek = 5
+
+// maxSummaryLength keeps issue summaries short enough for the per-issue table
+const maxSummaryLength = 50
+
+// Period is the date range a report covers
+type Period struct {
+	Name string    // "day" or "week"
+	From time.Time // Inclusive
+	To   time.Time // Exclusive
+}
+
+// Day returns the period covering the given date
+func Day(date time.Time) Period {
+	from := startOfDay(date)
+	return Period{Name: "day", From: from, To: from.AddDate(0, 0, 1)}
+}
+
+// Week returns the Monday-to-Sunday period containing the given date
+func Week(date time.Time) Period {
+	// time.Weekday starts on Sunday; shift so Monday is day 0
+	offset := (int(date.Weekday()) + 6) % 7
+	from := startOfDay(date).AddDate(0, 0, -offset)
+	return Period{Name: "week", From: from, To: from.AddDate(0, 0, 7)}
+}
+
+// Title returns a human-readable title (e.g., "Mon, Jan 6" or "Week of Jan 6 – Jan 12")
+func (p Period) Title() string {
+	if p.Name == "week" {
+		return fmt.Sprintf("Week of %s – %s", p.From.Format("Jan 2"), p.To.AddDate(0, 0, -1).Format("Jan 2"))
+	}
+	return p.From.Format("Mon, Jan 2")
+}
+
+// IssueTotal is the time logged to one issue in a report
+type IssueTotal struct {
+	Key     string
+	Summary string
+	Seconds int
+	Entries int
+}
+
+// Report summarizes time logged in a period against a target
+type Report struct {
+	Period        Period
+	Issues        []IssueTotal // Sorted by time logged, most first
+	TotalSeconds  int
+	TargetSeconds int
+}
+
+// Build aggregates entries per issue; dailyTargetSeconds is multiplied by the work days in a week for weekly reports
+func Build(entries []storage.TimeEntry, period Period, dailyTargetSeconds int) *Report {
+	r := &Report{Period: period, TargetSeconds: dailyTargetSeconds}
+	if period.Name == "week" {
+		r.TargetSeconds = dailyTargetSeconds * workDaysPerWeek
+	}
+
+	totals := make(map[string]*IssueTotal)
+	for _, entry := range entries {
+		total, ok := totals[entry.IssueKey]
+		if !ok {
+			total = &IssueTotal{Key: entry.IssueKey, Summary: entry.IssueSummary}
+			totals[entry.IssueKey] = total
+		}
+		total.Seconds += entry.TimeSpentSeconds
+		total.Entries++
+		r.TotalSeconds += entry.TimeSpentSeconds
+	}
+
+	for _, total := range totals {
+		r.Issues = append(r.Issues, *total)
+	}
+	sort.Slice(r.Issues, func(i, j int) bool {
+		if r.Issues[i].Seconds != r.Issues[j].Seconds {
+			return r.Issues[i].Seconds > r.Issues[j].Seconds
+		}
+		return r.Issues[i].Key < r.Issues[j].Key
+	})
+
+	return r
+}
+
+// GapSeconds returns how much time is missing to reach the target (negative when over target)
+func (r *Report) GapSeconds() int {
+	return r.TargetSeconds - r.TotalSeconds
+}
+
+// GapText describes the difference to the target (e.g., "1h 30m short of 8h target")
+func (r *Report) GapText() string {
+	gap := r.GapSeconds()
+	target := timeparse.Format(r.TargetSeconds)
+	switch {
+	case r.TargetSeconds == 0:
+		return "No target set"
+	case gap > 0:
+		return fmt.Sprintf("%s short of %s target", timeparse.Format(gap), target)
+	case gap < 0:
+		return fmt.Sprintf("%s over %s target", timeparse.Format(-gap), target)
+	default:
+		return fmt.Sprintf("%s target reached", target)
+	}
+}
+
+// Table renders the per-issue totals as aligned plain-text rows
+func (r *Report) Table() string {
+	if len(r.Issues) == 0 {
+		return "No time logged"
+	}
+
+	keyWidth := 0
+	for _, issue := range r.Issues {
+		keyWidth = max(keyWidth, len(issue.Key))
+	}
+
+	var sb strings.Builder
+	for _, issue := range r.Issues {
+		sb.WriteString(fmt.Sprintf("%-*s  %-7s  %s\n", keyWidth, issue.Key, timeparse.Format(issue.Seconds), truncate(issue.Summary, maxSummaryLength)))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// Text renders the report for the terminal and as the notification fallback text
+func (r *Report) Text() string {
+	return fmt.Sprintf("Worklog for %s\n\n%s\n\nTotal: %s (%s)",
+		r.Period.Title(), r.Table(), timeparse.Format(r.TotalSeconds), r.GapText())
+}
+
+// Blocks renders the report as Slack Block Kit blocks
+func (r *Report) Blocks() []slack.Block {
+	return []slack.Block{
+		slack.HeaderBlock(fmt.Sprintf("🗓️ Worklog for %s", r.Period.Title())),
+		slack.SectionBlock(fmt.Sprintf("```\n%s\n```", r.Table())),
+		slack.DividerBlock(),
+		slack.FieldsBlock(
+			fmt.Sprintf("*Total*\n%s", timeparse.Format(r.TotalSeconds)),
+			fmt.Sprintf("*Target*\n%s", r.GapText()),
+		),
+		slack.ContextBlock(fmt.Sprintf("%d issues · posted with tasklog", len(r.Issues))),
+	}
+}
+
+// truncate shortens text to at most limit characters, adding an ellipsis when cut
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// startOfDay returns midnight of the given day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/storage"
+)
+
+func TestWeek(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+	}{
+		{name: "monday", date: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+		{name: "wednesday", date: time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC)},
+		{name: "sunday", date: time.Date(2025, 1, 12, 23, 0, 0, 0, time.UTC)},
+	}
+
+	expectedFrom := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	expectedTo := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period := Week(tt.date)
+			if !period.From.Equal(expectedFrom) || !period.To.Equal(expectedTo) {
+				t.Errorf("expected %v - %v, got %v - %v", expectedFrom, expectedTo, period.From, period.To)
+			}
+		})
+	}
+
+	if title := Week(expectedFrom).Title(); title != "Week of Jan 6 – Jan 12" {
+		t.Errorf("unexpected title: %q", title)
+	}
+}
+
+func TestDay(t *testing.T) {
+	period := Day(time.Date(2025, 1, 6, 15, 30, 0, 0, time.UTC))
+
+	if !period.From.Equal(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)) || !period.To.Equal(time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected period: %+v", period)
+	}
+	if period.Title() != "Mon, Jan 6" {
+		t.Errorf("unexpected title: %q", period.Title())
+	}
+}
+
+func TestBuild(t *testing.T) {
+	entries := []storage.TimeEntry{
+		{IssueKey: "PROJ-1", IssueSummary: "Login page", TimeSpentSeconds: 3600},
+		{IssueKey: "PROJ-2", IssueSummary: "Standup", TimeSpentSeconds: 900},
+		{IssueKey: "PROJ-1", IssueSummary: "Login page", TimeSpentSeconds: 5400},
+	}
+
+	r := Build(entries, Day(time.Now()), 8*3600)
+
+	if r.TotalSeconds != 9900 {
+		t.Errorf("expected total 9900, got %d", r.TotalSeconds)
+	}
+	if len(r.Issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(r.Issues))
+	}
+	if r.Issues[0].Key != "PROJ-1" || r.Issues[0].Seconds != 9000 || r.Issues[0].Entries != 2 {
+		t.Errorf("unexpected first issue: %+v", r.Issues[0])
+	}
+	if r.GapText() != "5h 15m short of 8h target" {
+		t.Errorf("unexpected gap text: %q", r.GapText())
+	}
+
+	weekly := Build(entries, Week(time.Now()), 8*3600)
+	if weekly.TargetSeconds != 40*3600 {
+		t.Errorf("expected weekly target of 40h, got %d", weekly.TargetSeconds)
+	}
+}
+
+func TestGapText(t *testing.T) {
+	tests := []struct {
+		total    int
+		target   int
+		expected string
+	}{
+		{total: 8 * 3600, target: 8 * 3600, expected: "8h target reached"},
+		{total: 9 * 3600, target: 8 * 3600, expected: "1h over 8h target"},
+		{total: 3600, target: 0, expected: "No target set"},
+	}
+
+	for _, tt := range tests {
+		r := &Report{TotalSeconds: tt.total, TargetSeconds: tt.target}
+		if got := r.GapText(); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	r := Build([]storage.TimeEntry{
+		{IssueKey: "PROJ-1", IssueSummary: strings.Repeat("x", 80), TimeSpentSeconds: 3600},
+	}, Day(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)), 8*3600)
+
+	blocks := r.Blocks()
+
+	if blocks[0].Type != "header" || blocks[0].Text.Text != "🗓️ Worklog for Mon, Jan 6" {
+		t.Errorf("unexpected header block: %+v", blocks[0])
+	}
+
+	table := blocks[1].Text.Text
+	if !strings.HasPrefix(table, "```\nPROJ-1  1h") {
+		t.Errorf("expected per-issue table in a code block, got %q", table)
+	}
+	if !strings.Contains(table, "…") {
+		t.Errorf("expected long summary to be truncated, got %q", table)
+	}
+
+	if len(blocks[3].Fields) != 2 || blocks[3].Fields[1].Text != "*Target*\n7h short of 8h target" {
+		t.Errorf("unexpected totals block: %+v", blocks[3])
+	}
+}
+
+func TestTable_Empty(t *testing.T) {
+	r := Build(nil, Day(time.Now()), 8*3600)
+	if r.Table() != "No time logged" {
+		t.Errorf("unexpected empty table: %q", r.Table())
+	}
+}
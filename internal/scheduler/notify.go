@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Notify rings the terminal bell and shows a desktop notification where one is available
+// Desktop notifications use notify-send on Linux and osascript on macOS; failures are only logged
+func Notify(title, message string) {
+	// Terminal bell
+	fmt.Fprint(os.Stdout, "\a")
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			log.Debug().Msg("notify-send not found, skipping desktop notification")
+			return
+		}
+		cmd = exec.Command("notify-send", title, message)
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q sound name \"Glass\"", message, title)
+		cmd = exec.Command("osascript", "-e", script) //nolint:gosec // G204: script is built from config break names, quoted with %q
+	default:
+		return
+	}
+
+	if err := cmd.Run(); err != nil {
+		log.Debug().Err(err).Msg("Failed to show desktop notification")
+	}
+}
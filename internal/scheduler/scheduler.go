@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"tasklog/internal/config"
+)
+
+// Occurrence is a scheduled break falling due at a specific time
+type Occurrence struct {
+	Break    config.BreakEntry
+	At       time.Time // When the reminder fires (fixed time or window start)
+	Deadline time.Time // Latest time the break can be snoozed to (window end, or planned end for fixed times)
+	Window   bool      // Whether the occurrence comes from a time window rather than a fixed time
+}
+
+// CanSnooze reports whether snoozing for the given duration stays within the deadline
+func (o Occurrence) CanSnooze(now time.Time, snooze time.Duration) bool {
+	return !now.Add(snooze).After(o.Deadline)
+}
+
+// OccurrencesOn returns all scheduled breaks on the given day, ordered by time
+func OccurrencesOn(breaks []config.BreakEntry, day time.Time) []Occurrence {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+
+	var occurrences []Occurrence
+	for _, b := range breaks {
+		if b.Schedule == nil || !b.Schedule.AppliesOn(midnight.Weekday()) {
+			continue
+		}
+
+		for _, value := range b.Schedule.Times {
+			minutes, err := config.ParseClock(value)
+			if err != nil {
+				continue
+			}
+			at := midnight.Add(time.Duration(minutes) * time.Minute)
+			occurrences = append(occurrences, Occurrence{
+				Break:    b,
+				At:       at,
+				Deadline: at.Add(time.Duration(b.Duration) * time.Minute),
+			})
+		}
+
+		if b.Schedule.Window != "" {
+			start, end, err := config.ParseWindow(b.Schedule.Window)
+			if err != nil {
+				continue
+			}
+			occurrences = append(occurrences, Occurrence{
+				Break:    b,
+				At:       midnight.Add(time.Duration(start) * time.Minute),
+				Deadline: midnight.Add(time.Duration(end) * time.Minute),
+				Window:   true,
+			})
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].At.Before(occurrences[j].At)
+	})
+	return occurrences
+}
+
+// Due returns the occurrences that fall due after from and up to and including to
+func Due(breaks []config.BreakEntry, from, to time.Time) []Occurrence {
+	var due []Occurrence
+	for day := from; !startOfDay(day).After(to); day = startOfDay(day).AddDate(0, 0, 1) {
+		for _, occ := range OccurrencesOn(breaks, day) {
+			if occ.At.After(from) && !occ.At.After(to) {
+				due = append(due, occ)
+			}
+		}
+	}
+	return due
+}
+
+// Open returns window occurrences that have started but not yet ended at the given time
+// Used on startup so a window that is already open still gets a reminder
+func Open(breaks []config.BreakEntry, now time.Time) []Occurrence {
+	var open []Occurrence
+	for _, occ := range OccurrencesOn(breaks, now) {
+		if occ.Window && !occ.At.After(now) && now.Before(occ.Deadline) {
+			open = append(open, occ)
+		}
+	}
+	return open
+}
+
+// Next returns the first occurrence after the given time within the next week, or nil if nothing is scheduled
+func Next(breaks []config.BreakEntry, after time.Time) *Occurrence {
+	due := Due(breaks, after, after.AddDate(0, 0, 7))
+	if len(due) == 0 {
+		return nil
+	}
+	return &due[0]
+}
+
+// startOfDay returns midnight of the given day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
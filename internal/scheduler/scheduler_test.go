@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+)
+
+var testBreaks = []config.BreakEntry{
+	{
+		Name:     "lunch",
+		Duration: 60,
+		Schedule: &config.BreakSchedule{Times: []string{"12:30"}, Days: []string{"mon", "tue", "wed", "thu", "fri"}},
+	},
+	{
+		Name:     "prayer",
+		Duration: 15,
+		Schedule: &config.BreakSchedule{Window: "15:00-15:45"},
+	},
+	{
+		Name:     "coffee",
+		Duration: 10,
+	},
+}
+
+// monday is 2025-01-06, a Monday
+func monday(hour, minute int) time.Time {
+	return time.Date(2025, 1, 6, hour, minute, 0, 0, time.UTC)
+}
+
+func TestOccurrencesOn(t *testing.T) {
+	occurrences := OccurrencesOn(testBreaks, monday(8, 0))
+
+	if len(occurrences) != 2 {
+		t.Fatalf("expected 2 occurrences, got %d", len(occurrences))
+	}
+
+	lunch := occurrences[0]
+	if lunch.Break.Name != "lunch" || !lunch.At.Equal(monday(12, 30)) || !lunch.Deadline.Equal(monday(13, 30)) || lunch.Window {
+		t.Errorf("unexpected lunch occurrence: %+v", lunch)
+	}
+
+	prayer := occurrences[1]
+	if prayer.Break.Name != "prayer" || !prayer.At.Equal(monday(15, 0)) || !prayer.Deadline.Equal(monday(15, 45)) || !prayer.Window {
+		t.Errorf("unexpected prayer occurrence: %+v", prayer)
+	}
+}
+
+func TestOccurrencesOn_Weekend(t *testing.T) {
+	saturday := monday(8, 0).AddDate(0, 0, 5)
+
+	occurrences := OccurrencesOn(testBreaks, saturday)
+	if len(occurrences) != 1 || occurrences[0].Break.Name != "prayer" {
+		t.Errorf("expected only the every-day prayer break on Saturday, got %+v", occurrences)
+	}
+}
+
+func TestDue(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected []string
+	}{
+		{name: "nothing due", from: monday(9, 0), to: monday(9, 15), expected: nil},
+		{name: "fixed time", from: monday(12, 29), to: monday(12, 30), expected: []string{"lunch"}},
+		{name: "already fired", from: monday(12, 30), to: monday(12, 45), expected: nil},
+		{name: "window start", from: monday(14, 59), to: monday(15, 0), expected: []string{"prayer"}},
+		{name: "across suspend", from: monday(12, 0), to: monday(16, 0), expected: []string{"lunch", "prayer"}},
+		{name: "across midnight", from: monday(23, 0), to: monday(12, 30).AddDate(0, 0, 1), expected: []string{"lunch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due := Due(testBreaks, tt.from, tt.to)
+			if len(due) != len(tt.expected) {
+				t.Fatalf("expected %v, got %+v", tt.expected, due)
+			}
+			for i, name := range tt.expected {
+				if due[i].Break.Name != name {
+					t.Errorf("expected %s at position %d, got %s", name, i, due[i].Break.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if open := Open(testBreaks, monday(15, 10)); len(open) != 1 || open[0].Break.Name != "prayer" {
+		t.Errorf("expected prayer window to be open, got %+v", open)
+	}
+	if open := Open(testBreaks, monday(12, 45)); len(open) != 0 {
+		t.Errorf("expected fixed-time breaks not to be reported as open, got %+v", open)
+	}
+	if open := Open(testBreaks, monday(15, 45)); len(open) != 0 {
+		t.Errorf("expected window to be closed at its end, got %+v", open)
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Friday evening: next is Saturday's prayer window
+	friday := monday(18, 0).AddDate(0, 0, 4)
+	next := Next(testBreaks, friday)
+	if next == nil || next.Break.Name != "prayer" || next.At.Weekday() != time.Saturday {
+		t.Errorf("expected Saturday prayer, got %+v", next)
+	}
+
+	if Next([]config.BreakEntry{{Name: "coffee", Duration: 10}}, friday) != nil {
+		t.Error("expected nil when no breaks are scheduled")
+	}
+}
+
+func TestOccurrence_CanSnooze(t *testing.T) {
+	occ := Occurrence{At: monday(15, 0), Deadline: monday(15, 45), Window: true}
+
+	if !occ.CanSnooze(monday(15, 0), 5*time.Minute) {
+		t.Error("expected snooze within the window to be allowed")
+	}
+	if occ.CanSnooze(monday(15, 42), 5*time.Minute) {
+		t.Error("expected snooze past the window end to be refused")
+	}
+}
@@ -0,0 +1,45 @@
+package slack
+
+// Block is a Slack Block Kit layout block
+// Only the block types tasklog posts are supported: header, section, context and divider
+type Block struct {
+	Type     string       `json:"type"`
+	Text     *TextObject  `json:"text,omitempty"`
+	Fields   []TextObject `json:"fields,omitempty"`
+	Elements []TextObject `json:"elements,omitempty"`
+}
+
+// TextObject is a Block Kit text object
+type TextObject struct {
+	Type string `json:"type"` // "plain_text" or "mrkdwn"
+	Text string `json:"text"`
+}
+
+// HeaderBlock returns a header block with plain text
+func HeaderBlock(text string) Block {
+	return Block{Type: "header", Text: &TextObject{Type: "plain_text", Text: text}}
+}
+
+// SectionBlock returns a section block with markdown text
+func SectionBlock(markdown string) Block {
+	return Block{Type: "section", Text: &TextObject{Type: "mrkdwn", Text: markdown}}
+}
+
+// FieldsBlock returns a section block with markdown fields shown in two columns
+func FieldsBlock(fields ...string) Block {
+	block := Block{Type: "section"}
+	for _, field := range fields {
+		block.Fields = append(block.Fields, TextObject{Type: "mrkdwn", Text: field})
+	}
+	return block
+}
+
+// ContextBlock returns a context block with small markdown text
+func ContextBlock(markdown string) Block {
+	return Block{Type: "context", Elements: []TextObject{{Type: "mrkdwn", Text: markdown}}}
+}
+
+// DividerBlock returns a divider block
+func DividerBlock() Block {
+	return Block{Type: "divider"}
+}
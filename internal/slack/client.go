@@ -10,11 +10,14 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const defaultBaseURL = "https://slack.com/api"
+
 // Client represents a Slack API client
 type Client struct {
 	userToken  string
 	channelID  string
 	httpClient *http.Client
+	baseURL    string // Base URL for API (can be overridden in tests)
 }
 
 // NewClient creates a new Slack API client
@@ -25,15 +28,38 @@ func NewClient(userToken, channelID string) *Client {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		baseURL: defaultBaseURL,
 	}
 }
 
+// SetBaseURL sets the base URL for API requests (used for testing)
+func (c *Client) SetBaseURL(url string) {
+	c.baseURL = url
+}
+
+// AuthIdentity represents the user and workspace a token belongs to
+type AuthIdentity struct {
+	UserID string `json:"user_id"`
+	User   string `json:"user"`
+	TeamID string `json:"team_id"`
+	Team   string `json:"team"`
+}
+
+// Status represents a user's Slack status
+type Status struct {
+	Text       string `json:"status_text"`
+	Emoji      string `json:"status_emoji"`
+	Expiration int64  `json:"status_expiration"` // Unix time the status clears at, 0 for never
+}
+
 // SetStatus sets the user's Slack status
 func (c *Client) SetStatus(statusText, statusEmoji string, expirationMinutes int) error {
-	url := "https://slack.com/api/users.profile.set"
-
 	expiration := time.Now().Add(time.Duration(expirationMinutes) * time.Minute).Unix()
+	return c.SetStatusUntil(statusText, statusEmoji, expiration)
+}
 
+// SetStatusUntil sets the user's Slack status to expire at a Unix time (0 for never)
+func (c *Client) SetStatusUntil(statusText, statusEmoji string, expiration int64) error {
 	profile := map[string]interface{}{
 		"status_text":       statusText,
 		"status_emoji":      statusEmoji,
@@ -44,64 +70,205 @@ func (c *Client) SetStatus(statusText, statusEmoji string, expirationMinutes int
 		"profile": profile,
 	}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal status payload: %w", err)
+	if err := c.doRequest("users.profile.set", payload, nil); err != nil {
+		return err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create status request: %w", err)
-	}
+	log.Debug().
+		Str("status_text", statusText).
+		Str("status_emoji", statusEmoji).
+		Int64("expiration", expiration).
+		Msg("Slack status updated")
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.userToken))
+	return nil
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to set status: %w", err)
+// PostMessage posts a message to the configured channel
+func (c *Client) PostMessage(text string) error {
+	_, err := c.PostMessageInThread(text, "")
+	return err
+}
+
+// PostMessageInThread posts a message to the configured channel and returns its timestamp
+// threadTS, if set, posts the message as a reply in that thread
+func (c *Client) PostMessageInThread(text, threadTS string) (string, error) {
+	payload := map[string]interface{}{
+		"channel": c.channelID,
+		"text":    text,
+	}
+	if threadTS != "" {
+		payload["thread_ts"] = threadTS
 	}
-	defer resp.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode status response: %w", err)
+	var result struct {
+		TS string `json:"ts"`
+	}
+	if err := c.doRequest("chat.postMessage", payload, &result); err != nil {
+		return "", err
 	}
 
-	if ok, exists := result["ok"].(bool); !exists || !ok {
-		errorMsg := "unknown error"
-		if errStr, exists := result["error"].(string); exists {
-			errorMsg = errStr
-		}
-		return fmt.Errorf("slack API error: %s", errorMsg)
+	log.Debug().
+		Str("channel", c.channelID).
+		Str("ts", result.TS).
+		Str("thread_ts", threadTS).
+		Str("text", text).
+		Msg("Message posted to Slack")
+
+	return result.TS, nil
+}
+
+// UpdateMessage replaces the text of a message previously posted to the configured channel
+func (c *Client) UpdateMessage(ts, text string) error {
+	payload := map[string]interface{}{
+		"channel": c.channelID,
+		"ts":      ts,
+		"text":    text,
+	}
+
+	if err := c.doRequest("chat.update", payload, nil); err != nil {
+		return err
 	}
 
 	log.Debug().
-		Str("status_text", statusText).
-		Str("status_emoji", statusEmoji).
-		Int("expiration_minutes", expirationMinutes).
-		Msg("Slack status updated")
+		Str("channel", c.channelID).
+		Str("ts", ts).
+		Str("text", text).
+		Msg("Slack message updated")
 
 	return nil
 }
 
-// PostMessage posts a message to the configured channel
-func (c *Client) PostMessage(text string) error {
-	url := "https://slack.com/api/chat.postMessage"
+// GetStatus returns the user's current Slack status
+func (c *Client) GetStatus() (*Status, error) {
+	var result struct {
+		Profile Status `json:"profile"`
+	}
+	if err := c.doRequest("users.profile.get", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Profile, nil
+}
 
+// RestoreStatus puts back a previously saved status, or clears the status if it has expired since
+func (c *Client) RestoreStatus(status *Status) error {
+	if status == nil || status.Text == "" && status.Emoji == "" {
+		return c.ClearStatus()
+	}
+	if status.Expiration != 0 && status.Expiration <= time.Now().Unix() {
+		return c.ClearStatus()
+	}
+	return c.SetStatusUntil(status.Text, status.Emoji, status.Expiration)
+}
+
+// PostBlocks posts a Block Kit message to the configured channel and returns its timestamp
+// text is the notification and fallback text; threadTS, if set, posts the message as a thread reply
+func (c *Client) PostBlocks(text string, blocks []Block, threadTS string) (string, error) {
 	payload := map[string]interface{}{
 		"channel": c.channelID,
 		"text":    text,
+		"blocks":  blocks,
+	}
+	if threadTS != "" {
+		payload["thread_ts"] = threadTS
+	}
+
+	var result struct {
+		TS string `json:"ts"`
+	}
+	if err := c.doRequest("chat.postMessage", payload, &result); err != nil {
+		return "", err
+	}
+
+	log.Debug().
+		Str("channel", c.channelID).
+		Str("ts", result.TS).
+		Int("blocks", len(blocks)).
+		Msg("Block message posted to Slack")
+
+	return result.TS, nil
+}
+
+// Presence values accepted by SetPresence
+const (
+	PresenceAuto = "auto" // Let Slack decide based on activity
+	PresenceAway = "away" // Always show as away
+)
+
+// SetSnooze pauses notifications (Do Not Disturb) for the given number of minutes
+func (c *Client) SetSnooze(minutes int) error {
+	payload := map[string]interface{}{
+		"num_minutes": minutes,
+	}
+
+	if err := c.doRequest("dnd.setSnooze", payload, nil); err != nil {
+		return err
+	}
+
+	log.Debug().Int("minutes", minutes).Msg("Slack notifications snoozed")
+	return nil
+}
+
+// EndSnooze resumes notifications paused with SetSnooze
+// It succeeds when notifications are not snoozed
+func (c *Client) EndSnooze() error {
+	if err := c.doRequest("dnd.endSnooze", map[string]interface{}{}, nil); err != nil {
+		if err.Error() == "slack API error: snooze_not_active" {
+			return nil
+		}
+		return err
+	}
+
+	log.Debug().Msg("Slack notifications resumed")
+	return nil
+}
+
+// SetPresence sets the user's presence to PresenceAuto or PresenceAway
+func (c *Client) SetPresence(presence string) error {
+	payload := map[string]interface{}{
+		"presence": presence,
+	}
+
+	if err := c.doRequest("users.setPresence", payload, nil); err != nil {
+		return err
 	}
 
+	log.Debug().Str("presence", presence).Msg("Slack presence updated")
+	return nil
+}
+
+// ClearStatus clears the user's Slack status
+func (c *Client) ClearStatus() error {
+	return c.SetStatus("", "", 0)
+}
+
+// AuthTest verifies the token and returns the identity it belongs to
+func (c *Client) AuthTest() (*AuthIdentity, error) {
+	var identity AuthIdentity
+	if err := c.doRequest("auth.test", map[string]interface{}{}, &identity); err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Str("user", identity.User).
+		Str("team", identity.Team).
+		Msg("Slack token verified")
+
+	return &identity, nil
+}
+
+// doRequest calls a Slack Web API method and checks the "ok" field of the response
+// If result is non-nil, the response body is also decoded into it
+func (c *Client) doRequest(method string, payload interface{}, result interface{}) error {
+	url := fmt.Sprintf("%s/%s", c.baseURL, method)
+
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal message payload: %w", err)
+		return fmt.Errorf("failed to marshal %s payload: %w", method, err)
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create message request: %w", err)
+		return fmt.Errorf("failed to create %s request: %w", method, err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -109,32 +276,36 @@ func (c *Client) PostMessage(text string) error {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to post message: %w", err)
+		return fmt.Errorf("failed to call %s: %w", method, err)
 	}
 	defer resp.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode message response: %w", err)
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+
+	var status struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
 	}
 
-	if ok, exists := result["ok"].(bool); !exists || !ok {
-		errorMsg := "unknown error"
-		if errStr, exists := result["error"].(string); exists {
-			errorMsg = errStr
+	if !status.OK {
+		errorMsg := status.Error
+		if errorMsg == "" {
+			errorMsg = "unknown error"
 		}
 		return fmt.Errorf("slack API error: %s", errorMsg)
 	}
 
-	log.Debug().
-		Str("channel", c.channelID).
-		Str("text", text).
-		Msg("Message posted to Slack")
+	if result != nil {
+		if err := json.Unmarshal(body, result); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", method, err)
+		}
+	}
 
 	return nil
 }
-
-// ClearStatus clears the user's Slack status
-func (c *Client) ClearStatus() error {
-	return c.SetStatus("", "", 0)
-}
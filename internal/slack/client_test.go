@@ -1,7 +1,11 @@
 package slack
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -55,3 +59,304 @@ func TestClient_Structure(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth.test" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer xoxp-test" {
+			t.Errorf("unexpected authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"user":"jane","user_id":"U123","team":"Acme","team_id":"T123"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxp-test", "")
+	client.SetBaseURL(server.URL)
+
+	identity, err := client.AuthTest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if identity.User != "jane" || identity.Team != "Acme" || identity.UserID != "U123" {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+}
+
+func TestDoRequest_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("bad-token", "C123")
+	client.SetBaseURL(server.URL)
+
+	err := client.PostMessage("hello")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != "slack API error: invalid_auth" {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestPostMessage_Payload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload["channel"] != "C123" || payload["text"] != "hello" {
+			t.Errorf("unexpected payload: %v", payload)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxp-test", "C123")
+	client.SetBaseURL(server.URL)
+
+	if err := client.PostMessage("hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users.profile.get" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"profile":{"status_text":"In a meeting","status_emoji":":calendar:","status_expiration":1700000000}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxp-test", "")
+	client.SetBaseURL(server.URL)
+
+	status, err := client.GetStatus()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if status.Text != "In a meeting" || status.Emoji != ":calendar:" || status.Expiration != 1700000000 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestRestoreStatus(t *testing.T) {
+	future := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name               string
+		status             *Status
+		expectedText       string
+		expectedExpiration func(int64) bool
+	}{
+		{
+			name:               "restores status without expiration",
+			status:             &Status{Text: "Remote", Emoji: ":house:"},
+			expectedText:       "Remote",
+			expectedExpiration: func(exp int64) bool { return exp == 0 },
+		},
+		{
+			name:               "restores status with future expiration",
+			status:             &Status{Text: "Remote", Emoji: ":house:", Expiration: future},
+			expectedText:       "Remote",
+			expectedExpiration: func(exp int64) bool { return exp == future },
+		},
+		{
+			name:               "clears expired status",
+			status:             &Status{Text: "Lunch", Emoji: ":fork_and_knife:", Expiration: 1},
+			expectedText:       "",
+			expectedExpiration: func(exp int64) bool { return exp > 1 },
+		},
+		{
+			name:               "clears empty status",
+			status:             &Status{},
+			expectedText:       "",
+			expectedExpiration: func(exp int64) bool { return exp > 0 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload struct {
+				Profile Status `json:"profile"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode payload: %v", err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"ok":true}`))
+			}))
+			defer server.Close()
+
+			client := NewClient("xoxp-test", "")
+			client.SetBaseURL(server.URL)
+
+			if err := client.RestoreStatus(tt.status); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if payload.Profile.Text != tt.expectedText {
+				t.Errorf("expected status text %q, got %q", tt.expectedText, payload.Profile.Text)
+			}
+			if !tt.expectedExpiration(payload.Profile.Expiration) {
+				t.Errorf("unexpected expiration: %d", payload.Profile.Expiration)
+			}
+		})
+	}
+}
+
+func TestPostBlocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload["thread_ts"] != "1712345678.000100" {
+			t.Errorf("expected thread_ts in payload, got %v", payload["thread_ts"])
+		}
+		blocks, ok := payload["blocks"].([]interface{})
+		if !ok || len(blocks) != 2 {
+			t.Fatalf("expected 2 blocks, got %v", payload["blocks"])
+		}
+		header := blocks[0].(map[string]interface{})
+		if header["type"] != "header" {
+			t.Errorf("unexpected first block: %v", header)
+		}
+		if _, hasFields := blocks[1].(map[string]interface{})["fields"]; hasFields {
+			t.Error("expected empty fields to be omitted")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"ts":"1712345680.000200"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxp-test", "C123")
+	client.SetBaseURL(server.URL)
+
+	ts, err := client.PostBlocks("fallback", []Block{HeaderBlock("Title"), SectionBlock("*body*")}, "1712345678.000100")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ts != "1712345680.000200" {
+		t.Errorf("expected message timestamp, got %q", ts)
+	}
+}
+
+func TestPostMessageInThread(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload["thread_ts"] != "1712345678.000100" || payload["text"] != "hello" {
+			t.Errorf("unexpected payload: %v", payload)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"ts":"1712345680.000200"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxp-test", "C123")
+	client.SetBaseURL(server.URL)
+
+	ts, err := client.PostMessageInThread("hello", "1712345678.000100")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ts != "1712345680.000200" {
+		t.Errorf("expected message timestamp, got %q", ts)
+	}
+}
+
+func TestUpdateMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.update" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload["channel"] != "C123" || payload["ts"] != "1712345680.000200" || payload["text"] != "back after 47m" {
+			t.Errorf("unexpected payload: %v", payload)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxp-test", "C123")
+	client.SetBaseURL(server.URL)
+
+	if err := client.UpdateMessage("1712345680.000200", "back after 47m"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSnoozeAndPresence(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		calls = append(calls, r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/dnd.setSnooze":
+			if payload["num_minutes"] != float64(90) {
+				t.Errorf("unexpected snooze payload: %v", payload)
+			}
+			w.Write([]byte(`{"ok":true,"snooze_enabled":true}`))
+		case "/dnd.endSnooze":
+			w.Write([]byte(`{"ok":false,"error":"snooze_not_active"}`))
+		case "/users.setPresence":
+			if payload["presence"] != PresenceAway {
+				t.Errorf("unexpected presence payload: %v", payload)
+			}
+			w.Write([]byte(`{"ok":true}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxp-test", "C123")
+	client.SetBaseURL(server.URL)
+
+	if err := client.SetSnooze(90); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.SetPresence(PresenceAway); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.EndSnooze(); err != nil {
+		t.Errorf("expected snooze_not_active to be ignored, got %v", err)
+	}
+	if len(calls) != 3 {
+		t.Errorf("expected 3 calls, got %v", calls)
+	}
+}
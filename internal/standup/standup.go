@@ -0,0 +1,117 @@
+package standup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+// Item is one line of the standup notes
+type Item struct {
+	IssueKey string
+	Summary  string
+	Seconds  int      // Time logged (yesterday only)
+	Comments []string // Worklog comments (yesterday only)
+}
+
+// Notes is a "Yesterday / Today / Blockers" standup draft
+type Notes struct {
+	Date        time.Time // Day of the standup
+	PreviousDay time.Time // Workday covered by Yesterday
+	Yesterday   []Item
+	Today       []Item
+	Blockers    []Item
+}
+
+// PreviousWorkday returns the last weekday before the given date (Friday for a Monday)
+func PreviousWorkday(date time.Time) time.Time {
+	day := date.AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+}
+
+// Build creates standup notes from the previous workday's entries and the current Jira issues
+// Entries are grouped per issue in the order first logged; blocked issues are left out of Today
+func Build(date time.Time, entries []storage.TimeEntry, inProgress, blocked []jira.Issue) *Notes {
+	notes := &Notes{Date: date, PreviousDay: PreviousWorkday(date)}
+
+	index := make(map[string]int)
+	for _, entry := range entries {
+		i, ok := index[entry.IssueKey]
+		if !ok {
+			i = len(notes.Yesterday)
+			index[entry.IssueKey] = i
+			notes.Yesterday = append(notes.Yesterday, Item{IssueKey: entry.IssueKey, Summary: entry.IssueSummary})
+		}
+		notes.Yesterday[i].Seconds += entry.TimeSpentSeconds
+		if comment := strings.TrimSpace(entry.Comment); comment != "" {
+			notes.Yesterday[i].Comments = append(notes.Yesterday[i].Comments, comment)
+		}
+	}
+
+	blockedKeys := make(map[string]bool)
+	for _, issue := range blocked {
+		blockedKeys[issue.Key] = true
+		notes.Blockers = append(notes.Blockers, Item{IssueKey: issue.Key, Summary: issue.Fields.Summary})
+	}
+
+	for _, issue := range inProgress {
+		if !blockedKeys[issue.Key] {
+			notes.Today = append(notes.Today, Item{IssueKey: issue.Key, Summary: issue.Fields.Summary})
+		}
+	}
+
+	return notes
+}
+
+// Markdown renders the notes as a markdown draft
+func (n *Notes) Markdown() string {
+	var sb strings.Builder
+
+	yesterdayTitle := "Yesterday"
+	// Name the day when it wasn't literally yesterday (e.g., Friday on a Monday)
+	if !sameDay(n.PreviousDay.AddDate(0, 0, 1), n.Date) {
+		yesterdayTitle = fmt.Sprintf("Yesterday (%s)", n.PreviousDay.Format("Monday"))
+	}
+
+	sb.WriteString(fmt.Sprintf("**%s**\n", yesterdayTitle))
+	if len(n.Yesterday) == 0 {
+		sb.WriteString("- No time logged\n")
+	}
+	for _, item := range n.Yesterday {
+		sb.WriteString(fmt.Sprintf("- %s %s (%s)", item.IssueKey, item.Summary, timeparse.Format(item.Seconds)))
+		if len(item.Comments) > 0 {
+			sb.WriteString(": " + strings.Join(item.Comments, "; "))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n**Today**\n")
+	if len(n.Today) == 0 {
+		sb.WriteString("- Nothing in progress\n")
+	}
+	for _, item := range n.Today {
+		sb.WriteString(fmt.Sprintf("- %s %s\n", item.IssueKey, item.Summary))
+	}
+
+	sb.WriteString("\n**Blockers**\n")
+	if len(n.Blockers) == 0 {
+		sb.WriteString("- None\n")
+	}
+	for _, item := range n.Blockers {
+		sb.WriteString(fmt.Sprintf("- %s %s\n", item.IssueKey, item.Summary))
+	}
+
+	return sb.String()
+}
+
+// sameDay reports whether two times fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
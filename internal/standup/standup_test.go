@@ -0,0 +1,86 @@
+package standup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+)
+
+func issue(key, summary string) jira.Issue {
+	var i jira.Issue
+	i.Key = key
+	i.Fields.Summary = summary
+	return i
+}
+
+func TestPreviousWorkday(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected time.Time
+	}{
+		{name: "tuesday", date: time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC), expected: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{name: "monday", date: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC), expected: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{name: "sunday", date: time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC), expected: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PreviousWorkday(tt.date); !got.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	tuesday := time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)
+	entries := []storage.TimeEntry{
+		{IssueKey: "PROJ-1", IssueSummary: "Login page", TimeSpentSeconds: 3600, Comment: "Built the form"},
+		{IssueKey: "PROJ-2", IssueSummary: "Code review", TimeSpentSeconds: 1800},
+		{IssueKey: "PROJ-1", IssueSummary: "Login page", TimeSpentSeconds: 1800, Comment: "Added validation"},
+	}
+	inProgress := []jira.Issue{issue("PROJ-1", "Login page"), issue("PROJ-3", "Blocked thing")}
+	blocked := []jira.Issue{issue("PROJ-3", "Blocked thing")}
+
+	notes := Build(tuesday, entries, inProgress, blocked)
+
+	if len(notes.Yesterday) != 2 {
+		t.Fatalf("expected 2 issues for yesterday, got %d", len(notes.Yesterday))
+	}
+	if notes.Yesterday[0].Seconds != 5400 || len(notes.Yesterday[0].Comments) != 2 {
+		t.Errorf("unexpected grouping: %+v", notes.Yesterday[0])
+	}
+	if len(notes.Today) != 1 || notes.Today[0].IssueKey != "PROJ-1" {
+		t.Errorf("expected blocked issue to be left out of today, got %+v", notes.Today)
+	}
+
+	expected := `**Yesterday**
+- PROJ-1 Login page (1h 30m): Built the form; Added validation
+- PROJ-2 Code review (30m)
+
+**Today**
+- PROJ-1 Login page
+
+**Blockers**
+- PROJ-3 Blocked thing
+`
+	if got := notes.Markdown(); got != expected {
+		t.Errorf("unexpected markdown:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestMarkdown_EmptyAndMonday(t *testing.T) {
+	monday := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	output := Build(monday, nil, nil, nil).Markdown()
+
+	for _, s := range []string{"**Yesterday (Friday)**", "- No time logged", "- Nothing in progress", "- None"} {
+		if !strings.Contains(output, s) {
+			t.Errorf("expected output to contain %q, got:\n%s", s, output)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Break represents a break taken with 'tasklog break'
+type Break struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	StartedAt  time.Time  `json:"started_at"`
+	PlannedEnd time.Time  `json:"planned_end"`
+	EndedAt    *time.Time `json:"ended_at"`   // Set when ended early with 'tasklog back' or by a new break
+	MessageTS  string     `json:"message_ts"` // Timestamp of the Slack message announcing the break, if any
+}
+
+// IsActive reports whether the break is still running at the given time
+func (b *Break) IsActive(now time.Time) bool {
+	return b.EndedAt == nil && now.Before(b.PlannedEnd)
+}
+
+// End returns when the break ended, falling back to the planned end (or now, if earlier) for breaks not ended explicitly
+func (b *Break) End(now time.Time) time.Time {
+	if b.EndedAt != nil {
+		return *b.EndedAt
+	}
+	if now.Before(b.PlannedEnd) {
+		return now
+	}
+	return b.PlannedEnd
+}
+
+// Duration returns how long the break has lasted as of the given time
+func (b *Break) Duration(now time.Time) time.Duration {
+	return b.End(now).Sub(b.StartedAt)
+}
+
+// AddBreak records a new break
+func (s *Storage) AddBreak(b *Break) error {
+	log.Debug().
+		Str("name", b.Name).
+		Time("planned_end", b.PlannedEnd).
+		Msg("Adding break")
+
+	query := `INSERT INTO breaks (name, started_at, planned_end, ended_at, message_ts) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := s.db.Exec(query, b.Name, b.StartedAt, b.PlannedEnd, b.EndedAt, b.MessageTS)
+	if err != nil {
+		return fmt.Errorf("failed to insert break: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	b.ID = id
+	log.Debug().Int64("id", id).Msg("Break recorded")
+	return nil
+}
+
+// EndBreak marks a break as ended at the given time
+func (s *Storage) EndBreak(id int64, endedAt time.Time) error {
+	log.Debug().Int64("id", id).Msg("Ending break")
+
+	if _, err := s.db.Exec(`UPDATE breaks SET ended_at = ? WHERE id = ?`, endedAt, id); err != nil {
+		return fmt.Errorf("failed to end break: %w", err)
+	}
+	return nil
+}
+
+// SetBreakMessage stores the timestamp of the Slack message announcing a break
+func (s *Storage) SetBreakMessage(id int64, ts string) error {
+	if _, err := s.db.Exec(`UPDATE breaks SET message_ts = ? WHERE id = ?`, ts, id); err != nil {
+		return fmt.Errorf("failed to save break message: %w", err)
+	}
+	return nil
+}
+
+// GetActiveBreak returns the break running at the given time, or nil if there is none
+func (s *Storage) GetActiveBreak(now time.Time) (*Break, error) {
+	query := `
+		SELECT id, name, started_at, planned_end, ended_at, message_ts
+		FROM breaks
+		WHERE ended_at IS NULL AND planned_end > ?
+		ORDER BY started_at DESC
+		LIMIT 1
+	`
+
+	b, err := scanBreak(s.db.QueryRow(query, now))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active break: %w", err)
+	}
+	return b, nil
+}
+
+// GetExpiredBreaks returns breaks that ran past their planned end without being ended, oldest first
+func (s *Storage) GetExpiredBreaks(now time.Time) ([]Break, error) {
+	query := `
+		SELECT id, name, started_at, planned_end, ended_at, message_ts
+		FROM breaks
+		WHERE ended_at IS NULL AND planned_end <= ?
+		ORDER BY started_at ASC
+	`
+
+	rows, err := s.db.Query(query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired breaks: %w", err)
+	}
+	defer rows.Close()
+
+	var breaks []Break
+	for rows.Next() {
+		b, err := scanBreak(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan break: %w", err)
+		}
+		breaks = append(breaks, *b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating breaks: %w", err)
+	}
+	return breaks, nil
+}
+
+// GetTodayBreaks retrieves all breaks started today, oldest first
+func (s *Storage) GetTodayBreaks() ([]Break, error) {
+	log.Debug().Msg("Fetching today's breaks")
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	query := `
+		SELECT id, name, started_at, planned_end, ended_at, message_ts
+		FROM breaks
+		WHERE started_at >= ? AND started_at < ?
+		ORDER BY started_at ASC
+	`
+
+	rows, err := s.db.Query(query, startOfDay, endOfDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query breaks: %w", err)
+	}
+	defer rows.Close()
+
+	var breaks []Break
+	for rows.Next() {
+		b, err := scanBreak(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan break: %w", err)
+		}
+		breaks = append(breaks, *b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating breaks: %w", err)
+	}
+
+	log.Debug().Int("count", len(breaks)).Msg("Retrieved today's breaks")
+	return breaks, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanBreak reads a break from a row selected as id, name, started_at, planned_end, ended_at, message_ts
+func scanBreak(row rowScanner) (*Break, error) {
+	var b Break
+	var endedAt sql.NullTime
+	if err := row.Scan(&b.ID, &b.Name, &b.StartedAt, &b.PlannedEnd, &endedAt, &b.MessageTS); err != nil {
+		return nil, err
+	}
+	if endedAt.Valid {
+		b.EndedAt = &endedAt.Time
+	}
+	return &b, nil
+}
+
+// GetDailyThread returns the timestamp of the day's break thread in a Slack channel, or "" if none was started
+func (s *Storage) GetDailyThread(day time.Time, channelID string) (string, error) {
+	var ts string
+	err := s.db.QueryRow(`SELECT ts FROM daily_threads WHERE day = ? AND channel_id = ?`, day.Format("2006-01-02"), channelID).Scan(&ts)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query daily thread: %w", err)
+	}
+	return ts, nil
+}
+
+// SetDailyThread stores the timestamp of the day's break thread in a Slack channel
+func (s *Storage) SetDailyThread(day time.Time, channelID, ts string) error {
+	query := `INSERT OR REPLACE INTO daily_threads (day, channel_id, ts) VALUES (?, ?, ?)`
+	if _, err := s.db.Exec(query, day.Format("2006-01-02"), channelID, ts); err != nil {
+		return fmt.Errorf("failed to save daily thread: %w", err)
+	}
+	return nil
+}
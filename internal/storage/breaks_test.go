@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBreakLifecycle(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	b := &Break{
+		Name:       "lunch",
+		StartedAt:  now,
+		PlannedEnd: now.Add(60 * time.Minute),
+	}
+
+	if err := store.AddBreak(b); err != nil {
+		t.Fatalf("failed to add break: %v", err)
+	}
+	if b.ID == 0 {
+		t.Error("expected ID to be set after insert")
+	}
+
+	active, err := store.GetActiveBreak(now.Add(10 * time.Minute))
+	if err != nil {
+		t.Fatalf("failed to get active break: %v", err)
+	}
+	if active == nil || active.Name != "lunch" {
+		t.Fatalf("expected active lunch break, got %+v", active)
+	}
+
+	if err := store.EndBreak(b.ID, now.Add(30*time.Minute)); err != nil {
+		t.Fatalf("failed to end break: %v", err)
+	}
+
+	active, err = store.GetActiveBreak(now.Add(31 * time.Minute))
+	if err != nil {
+		t.Fatalf("failed to get active break: %v", err)
+	}
+	if active != nil {
+		t.Errorf("expected no active break after ending it, got %+v", active)
+	}
+
+	breaks, err := store.GetTodayBreaks()
+	if err != nil {
+		t.Fatalf("failed to get today's breaks: %v", err)
+	}
+	if len(breaks) != 1 {
+		t.Fatalf("expected 1 break, got %d", len(breaks))
+	}
+	if breaks[0].EndedAt == nil {
+		t.Fatal("expected ended_at to be set")
+	}
+	if got := breaks[0].Duration(now.Add(2 * time.Hour)); got != 30*time.Minute {
+		t.Errorf("expected duration 30m, got %v", got)
+	}
+}
+
+func TestGetActiveBreak_ExpiredBreak(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	if err := store.AddBreak(&Break{Name: "coffee", StartedAt: now.Add(-30 * time.Minute), PlannedEnd: now.Add(-15 * time.Minute)}); err != nil {
+		t.Fatalf("failed to add break: %v", err)
+	}
+
+	active, err := store.GetActiveBreak(now)
+	if err != nil {
+		t.Fatalf("failed to get active break: %v", err)
+	}
+	if active != nil {
+		t.Errorf("expected break past its planned end to be inactive, got %+v", active)
+	}
+}
+
+func TestGetExpiredBreaks(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	breaks := []*Break{
+		{Name: "coffee", StartedAt: now.Add(-30 * time.Minute), PlannedEnd: now.Add(-15 * time.Minute)},
+		{Name: "lunch", StartedAt: now.Add(-2 * time.Hour), PlannedEnd: now.Add(-time.Hour)},
+		{Name: "walk", StartedAt: now.Add(-5 * time.Minute), PlannedEnd: now.Add(10 * time.Minute)},
+	}
+	for _, b := range breaks {
+		if err := store.AddBreak(b); err != nil {
+			t.Fatalf("failed to add break: %v", err)
+		}
+	}
+	if err := store.EndBreak(breaks[1].ID, now.Add(-90*time.Minute)); err != nil {
+		t.Fatalf("failed to end break: %v", err)
+	}
+
+	expired, err := store.GetExpiredBreaks(now)
+	if err != nil {
+		t.Fatalf("failed to get expired breaks: %v", err)
+	}
+	if len(expired) != 1 || expired[0].Name != "coffee" {
+		t.Errorf("expected only the coffee break to have expired, got %+v", expired)
+	}
+}
+
+func TestBreak_Duration(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ended := start.Add(20 * time.Minute)
+
+	tests := []struct {
+		name     string
+		brk      Break
+		now      time.Time
+		expected time.Duration
+	}{
+		{
+			name:     "in progress",
+			brk:      Break{StartedAt: start, PlannedEnd: start.Add(time.Hour)},
+			now:      start.Add(10 * time.Minute),
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "ran to planned end",
+			brk:      Break{StartedAt: start, PlannedEnd: start.Add(time.Hour)},
+			now:      start.Add(3 * time.Hour),
+			expected: time.Hour,
+		},
+		{
+			name:     "ended early",
+			brk:      Break{StartedAt: start, PlannedEnd: start.Add(time.Hour), EndedAt: &ended},
+			now:      start.Add(3 * time.Hour),
+			expected: 20 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.brk.Duration(tt.now); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetBreakMessage(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	b := &Break{Name: "coffee", StartedAt: now, PlannedEnd: now.Add(10 * time.Minute)}
+	if err := store.AddBreak(b); err != nil {
+		t.Fatalf("failed to add break: %v", err)
+	}
+	if err := store.SetBreakMessage(b.ID, "1712345680.000200"); err != nil {
+		t.Fatalf("failed to set break message: %v", err)
+	}
+
+	active, err := store.GetActiveBreak(now)
+	if err != nil {
+		t.Fatalf("failed to get active break: %v", err)
+	}
+	if active == nil || active.MessageTS != "1712345680.000200" {
+		t.Errorf("expected message timestamp to be stored, got %+v", active)
+	}
+}
+
+func TestDailyThread(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	day := time.Date(2024, 4, 5, 9, 0, 0, 0, time.Local)
+
+	ts, err := store.GetDailyThread(day, "C123")
+	if err != nil {
+		t.Fatalf("failed to get daily thread: %v", err)
+	}
+	if ts != "" {
+		t.Errorf("expected no thread yet, got %q", ts)
+	}
+
+	if err := store.SetDailyThread(day, "C123", "1712345678.000100"); err != nil {
+		t.Fatalf("failed to set daily thread: %v", err)
+	}
+
+	ts, err = store.GetDailyThread(day.Add(8*time.Hour), "C123")
+	if err != nil {
+		t.Fatalf("failed to get daily thread: %v", err)
+	}
+	if ts != "1712345678.000100" {
+		t.Errorf("expected thread later the same day, got %q", ts)
+	}
+
+	if ts, _ := store.GetDailyThread(day.AddDate(0, 0, 1), "C123"); ts != "" {
+		t.Errorf("expected no thread the next day, got %q", ts)
+	}
+	if ts, _ := store.GetDailyThread(day, "C999"); ts != "" {
+		t.Errorf("expected no thread in another channel, got %q", ts)
+	}
+}
+
+func TestNewStorage_AddsMessageColumnToOldBreaks(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "old.db")
+
+	// Breaks table as created by schema version 3
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec(`CREATE TABLE breaks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		started_at DATETIME NOT NULL,
+		planned_end DATETIME NOT NULL,
+		ended_at DATETIME
+	)`)
+	if err != nil {
+		t.Fatalf("failed to create old breaks table: %v", err)
+	}
+	db.Close()
+
+	store, err := NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open old database: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	if err := store.AddBreak(&Break{Name: "lunch", StartedAt: now, PlannedEnd: now.Add(time.Hour), MessageTS: "1.2"}); err != nil {
+		t.Fatalf("failed to add break to migrated table: %v", err)
+	}
+}
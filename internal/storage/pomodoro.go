@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PomodoroSession is the progress of a running 'tasklog pomodoro', saved after every completed work
+// interval so the time can still be logged if the process doesn't get to log it
+type PomodoroSession struct {
+	IssueKey      string    `json:"issue_key"`
+	IssueSummary  string    `json:"issue_summary"`
+	StartedAt     time.Time `json:"started_at"` // When the first work interval started
+	Completed     int       `json:"completed"`  // Completed work intervals
+	WorkedSeconds int       `json:"worked_seconds"`
+	Label         string    `json:"label"` // Label given with --label, empty to ask
+}
+
+// SavePomodoroSession stores the progress of the pomodoro session, replacing any previous one
+func (s *Storage) SavePomodoroSession(session *PomodoroSession) error {
+	log.Debug().Str("issue", session.IssueKey).Int("completed", session.Completed).Msg("Saving pomodoro session")
+
+	query := `
+		INSERT OR REPLACE INTO pomodoro_session (
+			id, issue_key, issue_summary, started_at, completed, worked_seconds, label
+		) VALUES (1, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := s.db.Exec(
+		query,
+		session.IssueKey,
+		session.IssueSummary,
+		session.StartedAt,
+		session.Completed,
+		session.WorkedSeconds,
+		session.Label,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save pomodoro session: %w", err)
+	}
+	return nil
+}
+
+// GetPomodoroSession returns the saved pomodoro session, or nil if there is none
+func (s *Storage) GetPomodoroSession() (*PomodoroSession, error) {
+	query := `
+		SELECT issue_key, issue_summary, started_at, completed, worked_seconds, label
+		FROM pomodoro_session
+		WHERE id = 1
+	`
+
+	var session PomodoroSession
+	err := s.db.QueryRow(query).Scan(
+		&session.IssueKey,
+		&session.IssueSummary,
+		&session.StartedAt,
+		&session.Completed,
+		&session.WorkedSeconds,
+		&session.Label,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pomodoro session: %w", err)
+	}
+	return &session, nil
+}
+
+// ClearPomodoroSession removes the saved pomodoro session once its time is logged or discarded
+func (s *Storage) ClearPomodoroSession() error {
+	log.Debug().Msg("Clearing pomodoro session")
+
+	if _, err := s.db.Exec(`DELETE FROM pomodoro_session`); err != nil {
+		return fmt.Errorf("failed to clear pomodoro session: %w", err)
+	}
+	return nil
+}
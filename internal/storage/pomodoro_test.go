@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPomodoroSessionLifecycle(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	session, err := store.GetPomodoroSession()
+	if err != nil {
+		t.Fatalf("failed to get pomodoro session: %v", err)
+	}
+	if session != nil {
+		t.Fatalf("expected no session, got %+v", session)
+	}
+
+	started := time.Now().Add(-time.Hour)
+	for completed := 1; completed <= 2; completed++ {
+		err = store.SavePomodoroSession(&PomodoroSession{
+			IssueKey:      "PROJ-123",
+			IssueSummary:  "Test issue",
+			StartedAt:     started,
+			Completed:     completed,
+			WorkedSeconds: completed * 1500,
+			Label:         "development",
+		})
+		if err != nil {
+			t.Fatalf("failed to save pomodoro session: %v", err)
+		}
+	}
+
+	session, err = store.GetPomodoroSession()
+	if err != nil {
+		t.Fatalf("failed to get pomodoro session: %v", err)
+	}
+	if session == nil {
+		t.Fatal("expected a saved session")
+	}
+	if session.IssueKey != "PROJ-123" || session.Completed != 2 || session.WorkedSeconds != 3000 || session.Label != "development" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+	if !session.StartedAt.Equal(started) {
+		t.Errorf("expected start %v, got %v", started, session.StartedAt)
+	}
+
+	if err := store.ClearPomodoroSession(); err != nil {
+		t.Fatalf("failed to clear pomodoro session: %v", err)
+	}
+	session, err = store.GetPomodoroSession()
+	if err != nil {
+		t.Fatalf("failed to get pomodoro session: %v", err)
+	}
+	if session != nil {
+		t.Errorf("expected session to be cleared, got %+v", session)
+	}
+}
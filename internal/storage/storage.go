@@ -3,12 +3,16 @@ package storage
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	_ "modernc.org/sqlite"
 )
 
+// SchemaVersion is the current database schema version, stored in SQLite's user_version pragma
+const SchemaVersion = 9
+
 // Storage represents the SQLite storage layer
 type Storage struct {
 	db *sql.DB
@@ -29,6 +33,9 @@ type TimeEntry struct {
 	SyncedToTempo    bool      `json:"synced_to_tempo"`
 	JiraWorklogID    *string   `json:"jira_worklog_id"`
 	TempoWorklogID   *string   `json:"tempo_worklog_id"`
+	AdjustEstimate   string    `json:"adjust_estimate"` // How the worklog changes the remaining estimate (e.g., "leave", "new=2h"; empty for Jira's default)
+	Transition       string    `json:"transition"`      // Transition made on the issue when logging (e.g., "Done"; empty for none)
+	StartExact       bool      `json:"start_exact"`     // Started is the real start (ranges, timers) rather than the time of logging
 }
 
 // NewStorage creates a new storage instance
@@ -56,8 +63,26 @@ func (s *Storage) Close() error {
 	return s.db.Close()
 }
 
-// initSchema creates the database schema
+// initSchema creates the database schema, or migrates one recorded with an older schema version.
+// Databases from a newer tasklog are left untouched
 func (s *Storage) initSchema() error {
+	version, err := s.GetSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version == SchemaVersion {
+		return nil
+	}
+	if version > SchemaVersion {
+		log.Warn().
+			Int("version", version).
+			Int("supported", SchemaVersion).
+			Msg("Database was created by a newer tasklog; upgrade tasklog to avoid errors")
+		return nil
+	}
+
+	log.Debug().Int("from", version).Int("to", SchemaVersion).Msg("Migrating database schema")
+
 	schema := `
 	CREATE TABLE IF NOT EXISTS time_entries (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -72,22 +97,172 @@ func (s *Storage) initSchema() error {
 		synced_to_jira BOOLEAN NOT NULL DEFAULT 0,
 		synced_to_tempo BOOLEAN NOT NULL DEFAULT 0,
 		jira_worklog_id TEXT,
-		tempo_worklog_id TEXT
+		tempo_worklog_id TEXT,
+		adjust_estimate TEXT NOT NULL DEFAULT '',
+		transition TEXT NOT NULL DEFAULT '',
+		start_exact BOOLEAN NOT NULL DEFAULT 0
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_time_entries_issue_key ON time_entries(issue_key);
 	CREATE INDEX IF NOT EXISTS idx_time_entries_started ON time_entries(started);
 	CREATE INDEX IF NOT EXISTS idx_time_entries_created_at ON time_entries(created_at);
 	CREATE INDEX IF NOT EXISTS idx_time_entries_synced ON time_entries(synced_to_jira, synced_to_tempo);
+
+	CREATE TABLE IF NOT EXISTS breaks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		started_at DATETIME NOT NULL,
+		planned_end DATETIME NOT NULL,
+		ended_at DATETIME,
+		message_ts TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_breaks_started_at ON breaks(started_at);
+
+	CREATE TABLE IF NOT EXISTS active_timer (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		issue_key TEXT NOT NULL,
+		issue_summary TEXT NOT NULL,
+		started_at DATETIME NOT NULL,
+		previous_status_text TEXT NOT NULL DEFAULT '',
+		previous_status_emoji TEXT NOT NULL DEFAULT '',
+		previous_status_expiration INTEGER NOT NULL DEFAULT 0,
+		started_uptime INTEGER NOT NULL DEFAULT 0
+	);
+
+	CREATE TABLE IF NOT EXISTS idle_segments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME NOT NULL,
+		reason TEXT NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS pomodoro_session (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		issue_key TEXT NOT NULL,
+		issue_summary TEXT NOT NULL,
+		started_at DATETIME NOT NULL,
+		completed INTEGER NOT NULL,
+		worked_seconds INTEGER NOT NULL,
+		label TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE TABLE IF NOT EXISTS daily_threads (
+		day TEXT NOT NULL,
+		channel_id TEXT NOT NULL,
+		ts TEXT NOT NULL,
+		PRIMARY KEY (day, channel_id)
+	);
 	`
 
 	if _, err := s.db.Exec(schema); err != nil {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
 
+	// Columns added after a table was first released
+	if err := s.addColumnIfMissing("breaks", "message_ts", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("active_timer", "started_uptime", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("time_entries", "adjust_estimate", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("time_entries", "transition", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("time_entries", "start_exact", "BOOLEAN NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	// Record the schema version so the next run skips the migration
+	if _, err := s.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion)); err != nil {
+		return fmt.Errorf("failed to set schema version: %w", err)
+	}
+
+	return nil
+}
+
+// addColumnIfMissing adds a column to a table created by an older schema version
+func (s *Storage) addColumnIfMissing(table, column, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to read %s columns: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, colType    string
+			defaultValue     sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return fmt.Errorf("failed to scan %s columns: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating %s columns: %w", table, err)
+	}
+
+	log.Debug().Str("table", table).Str("column", column).Msg("Adding column to existing table")
+	if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add %s.%s column: %w", table, column, err)
+	}
 	return nil
 }
 
+// GetSchemaVersion returns the schema version recorded in the database
+func (s *Storage) GetSchemaVersion() (int, error) {
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// CheckIntegrity runs SQLite's integrity check and returns an error describing any problems found
+func (s *Storage) CheckIntegrity() error {
+	rows, err := s.db.Query("PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return fmt.Errorf("failed to read integrity check result: %w", err)
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating integrity check results: %w", err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database integrity check failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// CountUnsyncedEntries returns the number of entries not yet synced to Jira or Tempo
+func (s *Storage) CountUnsyncedEntries() (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM time_entries WHERE synced_to_jira = 0 OR synced_to_tempo = 0`
+	if err := s.db.QueryRow(query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unsynced entries: %w", err)
+	}
+	return count, nil
+}
+
 // AddTimeEntry adds a new time entry to the database
 func (s *Storage) AddTimeEntry(entry *TimeEntry) error {
 	log.Debug().
@@ -100,8 +275,8 @@ func (s *Storage) AddTimeEntry(entry *TimeEntry) error {
 		INSERT INTO time_entries (
 			issue_key, issue_summary, time_spent_seconds, time_spent,
 			label, comment, started, synced_to_jira, synced_to_tempo,
-			jira_worklog_id, tempo_worklog_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			jira_worklog_id, tempo_worklog_id, adjust_estimate, transition, start_exact
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := s.db.Exec(
@@ -117,6 +292,9 @@ func (s *Storage) AddTimeEntry(entry *TimeEntry) error {
 		entry.SyncedToTempo,
 		entry.JiraWorklogID,
 		entry.TempoWorklogID,
+		entry.AdjustEstimate,
+		entry.Transition,
+		entry.StartExact,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert time entry: %w", err)
@@ -141,7 +319,8 @@ func (s *Storage) UpdateTimeEntry(entry *TimeEntry) error {
 			synced_to_jira = ?,
 			synced_to_tempo = ?,
 			jira_worklog_id = ?,
-			tempo_worklog_id = ?
+			tempo_worklog_id = ?,
+			transition = ?
 		WHERE id = ?
 	`
 
@@ -151,6 +330,7 @@ func (s *Storage) UpdateTimeEntry(entry *TimeEntry) error {
 		entry.SyncedToTempo,
 		entry.JiraWorklogID,
 		entry.TempoWorklogID,
+		entry.Transition,
 		entry.ID,
 	)
 	if err != nil {
@@ -173,7 +353,7 @@ func (s *Storage) GetTodayEntries() ([]TimeEntry, error) {
 		SELECT 
 			id, issue_key, issue_summary, time_spent_seconds, time_spent,
 			label, comment, started, created_at, synced_to_jira, synced_to_tempo,
-			jira_worklog_id, tempo_worklog_id
+			jira_worklog_id, tempo_worklog_id, adjust_estimate, transition, start_exact
 		FROM time_entries
 		WHERE started >= ? AND started < ?
 		ORDER BY started DESC
@@ -185,35 +365,41 @@ func (s *Storage) GetTodayEntries() ([]TimeEntry, error) {
 	}
 	defer rows.Close()
 
-	var entries []TimeEntry
-	for rows.Next() {
-		var entry TimeEntry
-		err := rows.Scan(
-			&entry.ID,
-			&entry.IssueKey,
-			&entry.IssueSummary,
-			&entry.TimeSpentSeconds,
-			&entry.TimeSpent,
-			&entry.Label,
-			&entry.Comment,
-			&entry.Started,
-			&entry.CreatedAt,
-			&entry.SyncedToJira,
-			&entry.SyncedToTempo,
-			&entry.JiraWorklogID,
-			&entry.TempoWorklogID,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan time entry: %w", err)
-		}
-		entries = append(entries, entry)
+	entries, err := scanTimeEntries(rows)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating time entries: %w", err)
+	log.Debug().Int("count", len(entries)).Msg("Retrieved today's entries")
+	return entries, nil
+}
+
+// GetEntriesBetween retrieves time entries started in [from, to), oldest first
+func (s *Storage) GetEntriesBetween(from, to time.Time) ([]TimeEntry, error) {
+	log.Debug().Time("from", from).Time("to", to).Msg("Fetching entries")
+
+	query := `
+		SELECT 
+			id, issue_key, issue_summary, time_spent_seconds, time_spent,
+			label, comment, started, created_at, synced_to_jira, synced_to_tempo,
+			jira_worklog_id, tempo_worklog_id, adjust_estimate, transition, start_exact
+		FROM time_entries
+		WHERE started >= ? AND started < ?
+		ORDER BY started ASC
+	`
+
+	rows, err := s.db.Query(query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time entries: %w", err)
 	}
+	defer rows.Close()
 
-	log.Debug().Int("count", len(entries)).Msg("Retrieved today's entries")
+	entries, err := scanTimeEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Int("count", len(entries)).Msg("Retrieved entries")
 	return entries, nil
 }
 
@@ -225,7 +411,7 @@ func (s *Storage) GetUnsyncedEntries() ([]TimeEntry, error) {
 		SELECT 
 			id, issue_key, issue_summary, time_spent_seconds, time_spent,
 			label, comment, started, created_at, synced_to_jira, synced_to_tempo,
-			jira_worklog_id, tempo_worklog_id
+			jira_worklog_id, tempo_worklog_id, adjust_estimate, transition, start_exact
 		FROM time_entries
 		WHERE synced_to_jira = 0 OR synced_to_tempo = 0
 		ORDER BY started ASC
@@ -237,32 +423,9 @@ func (s *Storage) GetUnsyncedEntries() ([]TimeEntry, error) {
 	}
 	defer rows.Close()
 
-	var entries []TimeEntry
-	for rows.Next() {
-		var entry TimeEntry
-		err := rows.Scan(
-			&entry.ID,
-			&entry.IssueKey,
-			&entry.IssueSummary,
-			&entry.TimeSpentSeconds,
-			&entry.TimeSpent,
-			&entry.Label,
-			&entry.Comment,
-			&entry.Started,
-			&entry.CreatedAt,
-			&entry.SyncedToJira,
-			&entry.SyncedToTempo,
-			&entry.JiraWorklogID,
-			&entry.TempoWorklogID,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan time entry: %w", err)
-		}
-		entries = append(entries, entry)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating unsynced entries: %w", err)
+	entries, err := scanTimeEntries(rows)
+	if err != nil {
+		return nil, err
 	}
 
 	log.Debug().Int("count", len(entries)).Msg("Retrieved unsynced entries")
@@ -293,3 +456,39 @@ func (s *Storage) GetTodayTotalSeconds() (int, error) {
 
 	return int(total.Int64), nil
 }
+
+// scanTimeEntries reads all rows selected with the full time_entries column list
+func scanTimeEntries(rows *sql.Rows) ([]TimeEntry, error) {
+	var entries []TimeEntry
+	for rows.Next() {
+		var entry TimeEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.IssueKey,
+			&entry.IssueSummary,
+			&entry.TimeSpentSeconds,
+			&entry.TimeSpent,
+			&entry.Label,
+			&entry.Comment,
+			&entry.Started,
+			&entry.CreatedAt,
+			&entry.SyncedToJira,
+			&entry.SyncedToTempo,
+			&entry.JiraWorklogID,
+			&entry.TempoWorklogID,
+			&entry.AdjustEstimate,
+			&entry.Transition,
+			&entry.StartExact,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan time entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating time entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -266,3 +268,162 @@ func TestClose(t *testing.T) {
 		t.Errorf("failed to close storage: %v", err)
 	}
 }
+
+func TestGetSchemaVersion(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	version, err := store.GetSchemaVersion()
+	if err != nil {
+		t.Fatalf("failed to get schema version: %v", err)
+	}
+
+	if version != SchemaVersion {
+		t.Errorf("expected schema version %d, got %d", SchemaVersion, version)
+	}
+}
+
+func TestNewStorage_NewerSchemaVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasklog.db")
+	store, err := NewStorage(path)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	if _, err := store.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion+1)); err != nil {
+		t.Fatalf("failed to set schema version: %v", err)
+	}
+	store.Close()
+
+	store, err = NewStorage(path)
+	if err != nil {
+		t.Fatalf("failed to reopen storage: %v", err)
+	}
+	defer store.Close()
+
+	version, err := store.GetSchemaVersion()
+	if err != nil {
+		t.Fatalf("failed to get schema version: %v", err)
+	}
+	if version != SchemaVersion+1 {
+		t.Errorf("expected the newer schema version to be kept, got %d", version)
+	}
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.CheckIntegrity(); err != nil {
+		t.Errorf("expected fresh database to pass integrity check, got %v", err)
+	}
+}
+
+func TestCountUnsyncedEntries(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	entries := []*TimeEntry{
+		{IssueKey: "PROJ-1", IssueSummary: "One", TimeSpentSeconds: 60, TimeSpent: "1m", Label: "dev", Started: time.Now(), SyncedToJira: true, SyncedToTempo: true},
+		{IssueKey: "PROJ-2", IssueSummary: "Two", TimeSpentSeconds: 60, TimeSpent: "1m", Label: "dev", Started: time.Now(), SyncedToJira: false, SyncedToTempo: true},
+		{IssueKey: "PROJ-3", IssueSummary: "Three", TimeSpentSeconds: 60, TimeSpent: "1m", Label: "dev", Started: time.Now(), SyncedToJira: true, SyncedToTempo: false},
+	}
+	for _, entry := range entries {
+		if err := store.AddTimeEntry(entry); err != nil {
+			t.Fatalf("failed to add entry: %v", err)
+		}
+	}
+
+	count, err := store.CountUnsyncedEntries()
+	if err != nil {
+		t.Fatalf("failed to count unsynced entries: %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 unsynced entries, got %d", count)
+	}
+}
+
+func TestGetEntriesBetween(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	day := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	starts := []time.Time{
+		day.Add(-time.Hour),     // Previous day
+		day.Add(10 * time.Hour), // In range
+		day.Add(9 * time.Hour),  // In range, earlier
+		day.AddDate(0, 0, 1),    // Next day (exclusive end)
+	}
+	for i, started := range starts {
+		entry := &TimeEntry{
+			IssueKey:         fmt.Sprintf("PROJ-%d", i),
+			IssueSummary:     "Test issue",
+			TimeSpentSeconds: 3600,
+			TimeSpent:        "1h",
+			Label:            "development",
+			Started:          started,
+		}
+		if err := store.AddTimeEntry(entry); err != nil {
+			t.Fatalf("failed to add entry: %v", err)
+		}
+	}
+
+	entries, err := store.GetEntriesBetween(day, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].IssueKey != "PROJ-2" || entries[1].IssueKey != "PROJ-1" {
+		t.Errorf("expected entries oldest first, got %s then %s", entries[0].IssueKey, entries[1].IssueKey)
+	}
+}
+
+func TestAdjustEstimateAndTransitionAreStored(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	entry := &TimeEntry{
+		IssueKey:         "PROJ-123",
+		IssueSummary:     "Test issue",
+		TimeSpentSeconds: 3600,
+		TimeSpent:        "1h",
+		Label:            "development",
+		Started:          time.Now(),
+		AdjustEstimate:   "new=2h",
+	}
+	if err := store.AddTimeEntry(entry); err != nil {
+		t.Fatalf("failed to add time entry: %v", err)
+	}
+
+	// The transition is recorded once it has been made
+	entry.Transition = "Done"
+	if err := store.UpdateTimeEntry(entry); err != nil {
+		t.Fatalf("failed to update time entry: %v", err)
+	}
+
+	entries, err := store.GetUnsyncedEntries()
+	if err != nil {
+		t.Fatalf("failed to get unsynced entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].AdjustEstimate != "new=2h" || entries[0].Transition != "Done" {
+		t.Errorf("expected the estimate adjustment and transition to be stored, got %+v", entries)
+	}
+}
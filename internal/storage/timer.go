@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ActiveTimer is the task timer started with 'tasklog start'; at most one runs at a time
+type ActiveTimer struct {
+	IssueKey     string    `json:"issue_key"`
+	IssueSummary string    `json:"issue_summary"`
+	StartedAt    time.Time `json:"started_at"`
+
+	// Slack status in place before the timer changed it, restored on stop
+	PreviousStatusText       string `json:"previous_status_text"`
+	PreviousStatusEmoji      string `json:"previous_status_emoji"`
+	PreviousStatusExpiration int64  `json:"previous_status_expiration"`
+
+	// Time since boot, not counting suspend, when the timer started (0 if unknown); used to detect suspend
+	StartedUptime time.Duration `json:"started_uptime"`
+}
+
+// Idle segment reasons
+const (
+	IdleReasonIdle    = "idle"    // No keyboard or mouse input
+	IdleReasonSuspend = "suspend" // Machine asleep
+)
+
+// IdleSegment is a stretch of time while the timer ran in which the user was idle or the machine was asleep
+type IdleSegment struct {
+	ID        int64     `json:"id"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	Reason    string    `json:"reason"` // IdleReasonIdle or IdleReasonSuspend
+}
+
+// Duration returns the length of the segment
+func (seg *IdleSegment) Duration() time.Duration {
+	return seg.EndedAt.Sub(seg.StartedAt)
+}
+
+// StartTimer stores a new active timer, failing if one is already running
+func (s *Storage) StartTimer(timer *ActiveTimer) error {
+	log.Debug().Str("issue", timer.IssueKey).Msg("Starting timer")
+
+	query := `
+		INSERT INTO active_timer (
+			id, issue_key, issue_summary, started_at,
+			previous_status_text, previous_status_emoji, previous_status_expiration,
+			started_uptime
+		) VALUES (1, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := s.db.Exec(
+		query,
+		timer.IssueKey,
+		timer.IssueSummary,
+		timer.StartedAt,
+		timer.PreviousStatusText,
+		timer.PreviousStatusEmoji,
+		timer.PreviousStatusExpiration,
+		int64(timer.StartedUptime),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start timer (is one already running?): %w", err)
+	}
+	return nil
+}
+
+// GetActiveTimer returns the running timer, or nil if there is none
+func (s *Storage) GetActiveTimer() (*ActiveTimer, error) {
+	query := `
+		SELECT issue_key, issue_summary, started_at,
+			previous_status_text, previous_status_emoji, previous_status_expiration,
+			started_uptime
+		FROM active_timer
+		WHERE id = 1
+	`
+
+	var timer ActiveTimer
+	var startedUptime int64
+	err := s.db.QueryRow(query).Scan(
+		&timer.IssueKey,
+		&timer.IssueSummary,
+		&timer.StartedAt,
+		&timer.PreviousStatusText,
+		&timer.PreviousStatusEmoji,
+		&timer.PreviousStatusExpiration,
+		&startedUptime,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active timer: %w", err)
+	}
+	timer.StartedUptime = time.Duration(startedUptime)
+	return &timer, nil
+}
+
+// ClearTimer removes the active timer and its idle segments
+func (s *Storage) ClearTimer() error {
+	log.Debug().Msg("Clearing timer")
+
+	if _, err := s.db.Exec(`DELETE FROM active_timer`); err != nil {
+		return fmt.Errorf("failed to clear timer: %w", err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM idle_segments`); err != nil {
+		return fmt.Errorf("failed to clear idle segments: %w", err)
+	}
+	return nil
+}
+
+// AddIdleSegment records an idle segment of the running timer
+func (s *Storage) AddIdleSegment(seg *IdleSegment) error {
+	log.Debug().
+		Str("reason", seg.Reason).
+		Time("started_at", seg.StartedAt).
+		Time("ended_at", seg.EndedAt).
+		Msg("Adding idle segment")
+
+	query := `INSERT INTO idle_segments (started_at, ended_at, reason) VALUES (?, ?, ?)`
+	result, err := s.db.Exec(query, seg.StartedAt, seg.EndedAt, seg.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to insert idle segment: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+	seg.ID = id
+	return nil
+}
+
+// UpdateIdleSegmentEnd moves the end of an idle segment that is still growing
+func (s *Storage) UpdateIdleSegmentEnd(id int64, endedAt time.Time) error {
+	if _, err := s.db.Exec(`UPDATE idle_segments SET ended_at = ? WHERE id = ?`, endedAt, id); err != nil {
+		return fmt.Errorf("failed to update idle segment: %w", err)
+	}
+	return nil
+}
+
+// GetIdleSegments returns the idle segments of the running timer, oldest first
+func (s *Storage) GetIdleSegments() ([]IdleSegment, error) {
+	rows, err := s.db.Query(`SELECT id, started_at, ended_at, reason FROM idle_segments ORDER BY started_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idle segments: %w", err)
+	}
+	defer rows.Close()
+
+	var segments []IdleSegment
+	for rows.Next() {
+		var seg IdleSegment
+		if err := rows.Scan(&seg.ID, &seg.StartedAt, &seg.EndedAt, &seg.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan idle segment: %w", err)
+		}
+		segments = append(segments, seg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating idle segments: %w", err)
+	}
+	return segments, nil
+}
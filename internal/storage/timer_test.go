@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerLifecycle(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		t.Fatalf("failed to get active timer: %v", err)
+	}
+	if timer != nil {
+		t.Fatalf("expected no timer, got %+v", timer)
+	}
+
+	started := time.Now().Add(-30 * time.Minute)
+	err = store.StartTimer(&ActiveTimer{
+		IssueKey:                 "PROJ-123",
+		IssueSummary:             "Test issue",
+		StartedAt:                started,
+		PreviousStatusText:       "Remote",
+		PreviousStatusEmoji:      ":house:",
+		PreviousStatusExpiration: 1700000000,
+	})
+	if err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+
+	if err := store.StartTimer(&ActiveTimer{IssueKey: "PROJ-456", IssueSummary: "Other", StartedAt: time.Now()}); err == nil {
+		t.Error("expected error starting a second timer")
+	}
+
+	timer, err = store.GetActiveTimer()
+	if err != nil {
+		t.Fatalf("failed to get active timer: %v", err)
+	}
+	if timer == nil || timer.IssueKey != "PROJ-123" || timer.PreviousStatusText != "Remote" || timer.PreviousStatusExpiration != 1700000000 {
+		t.Fatalf("unexpected timer: %+v", timer)
+	}
+	if !timer.StartedAt.Equal(started) {
+		t.Errorf("expected start %v, got %v", started, timer.StartedAt)
+	}
+
+	if err := store.ClearTimer(); err != nil {
+		t.Fatalf("failed to clear timer: %v", err)
+	}
+
+	timer, err = store.GetActiveTimer()
+	if err != nil {
+		t.Fatalf("failed to get active timer: %v", err)
+	}
+	if timer != nil {
+		t.Errorf("expected timer to be cleared, got %+v", timer)
+	}
+}
+
+func TestIdleSegments(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	if err := store.StartTimer(&ActiveTimer{IssueKey: "PROJ-1", IssueSummary: "Task", StartedAt: start, StartedUptime: 90 * time.Minute}); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		t.Fatalf("failed to get timer: %v", err)
+	}
+	if timer.StartedUptime != 90*time.Minute {
+		t.Errorf("expected uptime to be stored, got %s", timer.StartedUptime)
+	}
+
+	seg := &IdleSegment{StartedAt: start.Add(time.Hour), EndedAt: start.Add(70 * time.Minute), Reason: IdleReasonIdle}
+	if err := store.AddIdleSegment(seg); err != nil {
+		t.Fatalf("failed to add idle segment: %v", err)
+	}
+	if err := store.UpdateIdleSegmentEnd(seg.ID, start.Add(80*time.Minute)); err != nil {
+		t.Fatalf("failed to update idle segment: %v", err)
+	}
+
+	segments, err := store.GetIdleSegments()
+	if err != nil {
+		t.Fatalf("failed to get idle segments: %v", err)
+	}
+	if len(segments) != 1 || segments[0].Duration() != 20*time.Minute || segments[0].Reason != IdleReasonIdle {
+		t.Fatalf("unexpected idle segments: %+v", segments)
+	}
+
+	if err := store.ClearTimer(); err != nil {
+		t.Fatalf("failed to clear timer: %v", err)
+	}
+	segments, err = store.GetIdleSegments()
+	if err != nil {
+		t.Fatalf("failed to get idle segments: %v", err)
+	}
+	if len(segments) != 0 {
+		t.Errorf("expected idle segments to be cleared with the timer, got %d", len(segments))
+	}
+}
@@ -11,9 +11,15 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// TokenSource provides OAuth access tokens, refreshing them as needed
+type TokenSource interface {
+	AccessToken() (string, error)
+}
+
 // Client represents a Tempo API client
 type Client struct {
 	apiToken   string
+	tokens     TokenSource
 	httpClient *http.Client
 }
 
@@ -27,6 +33,13 @@ func NewClient(apiToken string) *Client {
 	}
 }
 
+// NewOAuthClient creates a Tempo API client that authenticates with tokens from 'tasklog login'
+func NewOAuthClient(tokens TokenSource) *Client {
+	client := NewClient("")
+	client.tokens = tokens
+	return client
+}
+
 // WorklogRequest represents a request to create a worklog in Tempo
 type WorklogRequest struct {
 	IssueID          string             `json:"issueId"` // Numeric issue ID (required in v4)
@@ -172,7 +185,15 @@ func (c *Client) doRequest(method, url string, body interface{}, result interfac
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
+	apiToken := c.apiToken
+	if c.tokens != nil {
+		apiToken, err = c.tokens.AccessToken()
+		if err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiToken))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -1,6 +1,8 @@
 package tempo
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -25,6 +27,26 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+// failingTokens is a TokenSource that can't provide a token
+type failingTokens struct{}
+
+func (failingTokens) AccessToken() (string, error) {
+	return "", errors.New("not logged in")
+}
+
+func TestNewOAuthClient_UsesTokenSource(t *testing.T) {
+	client := NewOAuthClient(failingTokens{})
+
+	if client.tokens == nil {
+		t.Fatal("expected token source to be set")
+	}
+
+	// The token is fetched before anything is sent
+	if _, err := client.GetTodayWorklogs("abc"); err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("expected the token error, got %v", err)
+	}
+}
+
 func TestFormatSeconds(t *testing.T) {
 	tests := []struct {
 		seconds  int
@@ -0,0 +1,105 @@
+package timeparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitRest is the time value that gives a part whatever is left of the total
+const SplitRest = "rest"
+
+// SplitPart is one task's share of a split duration
+type SplitPart struct {
+	Key     string // Task key (e.g., "PROJ-1")
+	Seconds int    // Time for this task (filled in for the rest part by ResolveSplit)
+	Rest    bool   // Takes whatever is left of the total
+}
+
+// ParseSplit parses a split like "PROJ-1=1h,PROJ-2=45m,PROJ-3=rest"
+// At most one part may use "rest"
+func ParseSplit(spec string) ([]SplitPart, error) {
+	return DefaultRules.ParseSplit(spec)
+}
+
+// ParseSplit parses a split, rounding each part's time by the rules
+func (r Rules) ParseSplit(spec string) ([]SplitPart, error) {
+	var parts []SplitPart
+	seen := make(map[string]bool)
+	hasRest := false
+
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(item, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid split part %q (expected TASK=time, e.g. PROJ-1=1h)", item)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("task %s appears more than once", key)
+		}
+		seen[key] = true
+
+		if strings.EqualFold(value, SplitRest) {
+			if hasRest {
+				return nil, fmt.Errorf("only one task can take the rest")
+			}
+			hasRest = true
+			parts = append(parts, SplitPart{Key: key, Rest: true})
+			continue
+		}
+
+		seconds, err := r.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		parts = append(parts, SplitPart{Key: key, Seconds: seconds})
+	}
+
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("a split needs at least two tasks")
+	}
+	return parts, nil
+}
+
+// ResolveSplit checks the parts against the total and gives the rest part what is left
+// A total of 0 means no total was given: the parts must then all have a time, and the total is their sum
+func ResolveSplit(parts []SplitPart, totalSeconds int) ([]SplitPart, int, error) {
+	assigned := 0
+	restIndex := -1
+	for i, part := range parts {
+		if part.Rest {
+			restIndex = i
+			continue
+		}
+		assigned += part.Seconds
+	}
+
+	if totalSeconds == 0 {
+		if restIndex >= 0 {
+			return nil, 0, fmt.Errorf("%s=rest needs the total time (--time)", parts[restIndex].Key)
+		}
+		return parts, assigned, nil
+	}
+
+	resolved := make([]SplitPart, len(parts))
+	copy(resolved, parts)
+
+	if restIndex < 0 {
+		if assigned != totalSeconds {
+			return nil, 0, fmt.Errorf("the parts add up to %s but the total is %s", Format(assigned), Format(totalSeconds))
+		}
+		return resolved, totalSeconds, nil
+	}
+
+	rest := totalSeconds - assigned
+	if rest <= 0 {
+		return nil, 0, fmt.Errorf("the parts add up to %s, leaving nothing of %s for %s", Format(assigned), Format(totalSeconds), parts[restIndex].Key)
+	}
+	resolved[restIndex].Seconds = rest
+	return resolved, totalSeconds, nil
+}
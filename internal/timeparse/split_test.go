@@ -0,0 +1,102 @@
+package timeparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSplit(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expected      []SplitPart
+		expectedError bool
+	}{
+		{
+			name: "times and rest",
+			spec: "PROJ-1=1h, proj-2=45m,PROJ-3=rest",
+			expected: []SplitPart{
+				{Key: "PROJ-1", Seconds: 3600},
+				{Key: "PROJ-2", Seconds: 2700},
+				{Key: "PROJ-3", Rest: true},
+			},
+		},
+		{
+			name: "times only",
+			spec: "PROJ-1=1h,PROJ-2=30m",
+			expected: []SplitPart{
+				{Key: "PROJ-1", Seconds: 3600},
+				{Key: "PROJ-2", Seconds: 1800},
+			},
+		},
+		{name: "single task", spec: "PROJ-1=1h", expectedError: true},
+		{name: "missing time", spec: "PROJ-1=1h,PROJ-2", expectedError: true},
+		{name: "invalid time", spec: "PROJ-1=1h,PROJ-2=soon", expectedError: true},
+		{name: "two rests", spec: "PROJ-1=rest,PROJ-2=rest", expectedError: true},
+		{name: "duplicate task", spec: "PROJ-1=1h,proj-1=30m", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := ParseSplit(tt.spec)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got %+v", parts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(parts, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, parts)
+			}
+		})
+	}
+}
+
+func TestResolveSplit(t *testing.T) {
+	withRest := []SplitPart{{Key: "PROJ-1", Seconds: 3600}, {Key: "PROJ-2", Seconds: 2700}, {Key: "PROJ-3", Rest: true}}
+	fixed := []SplitPart{{Key: "PROJ-1", Seconds: 3600}, {Key: "PROJ-2", Seconds: 1800}}
+
+	tests := []struct {
+		name          string
+		parts         []SplitPart
+		total         int
+		expectedRest  int
+		expectedTotal int
+		expectedError bool
+	}{
+		{name: "rest gets remainder", parts: withRest, total: 10800, expectedRest: 4500, expectedTotal: 10800},
+		{name: "rest without total", parts: withRest, total: 0, expectedError: true},
+		{name: "nothing left for rest", parts: withRest, total: 6300, expectedError: true},
+		{name: "fixed parts without total", parts: fixed, total: 0, expectedTotal: 5400},
+		{name: "fixed parts matching total", parts: fixed, total: 5400, expectedTotal: 5400},
+		{name: "fixed parts not matching total", parts: fixed, total: 7200, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, total, err := ResolveSplit(tt.parts, tt.total)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got %+v", resolved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if total != tt.expectedTotal {
+				t.Errorf("expected total %d, got %d", tt.expectedTotal, total)
+			}
+			if tt.expectedRest != 0 && resolved[len(resolved)-1].Seconds != tt.expectedRest {
+				t.Errorf("expected rest %d, got %d", tt.expectedRest, resolved[len(resolved)-1].Seconds)
+			}
+		})
+	}
+
+	if withRest[2].Seconds != 0 {
+		t.Error("expected input parts to be left unchanged")
+	}
+}
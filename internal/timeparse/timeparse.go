@@ -3,15 +3,84 @@ package timeparse
 import (
 	"fmt"
 	"math"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	str2duration "github.com/xhit/go-str2duration/v2"
 )
 
+// RoundMode is the direction durations are rounded in
+type RoundMode string
+
+// Supported rounding modes
+const (
+	RoundNearest RoundMode = "nearest"
+	RoundUp      RoundMode = "up"
+	RoundDown    RoundMode = "down"
+)
+
+// Rules controls how time input is rounded and how long "1d" is
+type Rules struct {
+	RoundTo   time.Duration // Rounding granularity (e.g., 5 minutes)
+	RoundMode RoundMode     // Rounding direction
+	Workday   time.Duration // Length of one day in "1d" and "0.5d"
+}
+
+// DefaultRules rounds to the nearest 5 minutes with an 8-hour workday
+var DefaultRules = Rules{RoundTo: 5 * time.Minute, RoundMode: RoundNearest, Workday: 8 * time.Hour}
+
+// Entry is parsed time input for a worklog
+// Started is set when the input fixes the start time (ranges and "since"), and zero otherwise
+type Entry struct {
+	Seconds int
+	Started time.Time
+}
+
+var (
+	daysPattern  = regexp.MustCompile(`(\d+(?:\.\d+)?)d`)
+	rangePattern = regexp.MustCompile(`^(\d{1,2}(?::\d{2})?)\s*-\s*(\d{1,2}(?::\d{2})?)$`)
+	sincePattern = regexp.MustCompile(`^since\s+(\d{1,2}(?::\d{2})?)$`)
+)
+
 // Parse parses a time string and returns the duration in seconds
 // Supports various formats using go-str2duration library
 // Rounds to the nearest 5 minutes
 func Parse(input string) (int, error) {
+	return DefaultRules.Parse(input)
+}
+
+// Parse parses a duration like "2h 30m" or "0.5d" and returns it in seconds, rounded by the rules
+func (r Rules) Parse(input string) (int, error) {
+	duration, err := r.parseDuration(input)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("time must be positive")
+	}
+
+	return r.roundWorked(input, duration)
+}
+
+// ParseEstimate parses a remaining estimate like Parse, but also accepts zero (e.g., "0m" when no work is left)
+func (r Rules) ParseEstimate(input string) (int, error) {
+	duration, err := r.parseDuration(input)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("estimate must not be negative")
+	}
+
+	return r.Round(duration), nil
+}
+
+// parseDuration normalizes a duration like "2 hours 30 min" or "0.5d" and parses it
+func (r Rules) parseDuration(input string) (time.Duration, error) {
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return 0, fmt.Errorf("empty time input")
@@ -21,6 +90,7 @@ func Parse(input string) (int, error) {
 	// 1. Convert to lowercase for case insensitivity
 	// 2. Replace full words with abbreviations
 	// 3. Remove spaces between numbers and units
+	// 4. Turn workdays into minutes
 	normalized := strings.ToLower(input)
 	normalized = strings.ReplaceAll(normalized, "hours", "h")
 	normalized = strings.ReplaceAll(normalized, "hour", "h")
@@ -28,30 +98,138 @@ func Parse(input string) (int, error) {
 	normalized = strings.ReplaceAll(normalized, "minute", "m")
 	normalized = strings.ReplaceAll(normalized, "mins", "m")
 	normalized = strings.ReplaceAll(normalized, "min", "m")
+	normalized = strings.ReplaceAll(normalized, "days", "d")
+	normalized = strings.ReplaceAll(normalized, "day", "d")
 	normalized = strings.ReplaceAll(normalized, " ", "")
+	normalized = daysPattern.ReplaceAllStringFunc(normalized, func(days string) string {
+		count, _ := strconv.ParseFloat(strings.TrimSuffix(days, "d"), 64)
+		return strconv.FormatFloat(count*r.workday().Minutes(), 'f', -1, 64) + "m"
+	})
 
 	// Parse using the library
 	duration, err := str2duration.ParseDuration(normalized)
 	if err != nil {
-		return 0, fmt.Errorf("invalid time format: %s (expected formats: 2h 30m, 2.5h, 150m, 2h30m)", input)
+		return 0, fmt.Errorf("invalid time format: %s (expected formats: 2h 30m, 2.5h, 150m, 2h30m, 0.5d)", input)
 	}
+	return duration, nil
+}
 
-	if duration <= 0 {
-		return 0, fmt.Errorf("time must be positive")
+// ParseEntry parses worklog time input: a duration, a clock range like "09:15-11:40",
+// or "since 14:00" (until now); ranges and "since" refer to the day of now
+func (r Rules) ParseEntry(input string, now time.Time) (Entry, error) {
+	normalized := strings.ToLower(strings.TrimSpace(input))
+
+	if match := sincePattern.FindStringSubmatch(normalized); match != nil {
+		start, err := parseClock(match[1], now)
+		if err != nil {
+			return Entry{}, err
+		}
+		if !start.Before(now) {
+			return Entry{}, fmt.Errorf("%s is not in the past", match[1])
+		}
+		seconds, err := r.roundWorked(input, now.Sub(start))
+		if err != nil {
+			return Entry{}, err
+		}
+		return Entry{Seconds: seconds, Started: start}, nil
+	}
+
+	if match := rangePattern.FindStringSubmatch(normalized); match != nil {
+		start, err := parseClock(match[1], now)
+		if err != nil {
+			return Entry{}, err
+		}
+		end, err := parseClock(match[2], now)
+		if err != nil {
+			return Entry{}, err
+		}
+		if !end.After(start) {
+			return Entry{}, fmt.Errorf("time range %s ends before it starts", input)
+		}
+		seconds, err := r.roundWorked(input, end.Sub(start))
+		if err != nil {
+			return Entry{}, err
+		}
+		return Entry{Seconds: seconds, Started: start}, nil
+	}
+
+	if strings.Contains(normalized, ":") || strings.HasPrefix(normalized, "since") {
+		return Entry{}, fmt.Errorf("invalid time: %s (expected a range like 09:15-11:40 or since 14:00)", input)
+	}
+
+	seconds, err := r.Parse(input)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{Seconds: seconds}, nil
+}
+
+// Round rounds a duration to the rules' granularity and returns it in seconds
+func (r Rules) Round(d time.Duration) int {
+	step := r.RoundTo
+	if step <= 0 {
+		step = time.Minute
 	}
 
-	// Convert to minutes for rounding
-	totalMinutes := duration.Minutes()
+	units := d.Seconds() / step.Seconds()
+	switch r.RoundMode {
+	case RoundUp:
+		units = math.Ceil(units)
+	case RoundDown:
+		units = math.Floor(units)
+	default:
+		units = math.Round(units)
+	}
+	return int(units * step.Seconds())
+}
 
-	// Round to nearest 5 minutes
-	roundedMinutes := roundToNearest5(totalMinutes)
+// roundWorked rounds time worked, refusing input that rounds to nothing since it can't be logged
+func (r Rules) roundWorked(input string, d time.Duration) (int, error) {
+	seconds := r.Round(d)
+	if seconds == 0 {
+		return 0, fmt.Errorf("%s is too short to log when %s", strings.TrimSpace(input), r.Describe())
+	}
+	return seconds, nil
+}
 
-	return int(roundedMinutes * 60), nil
+// Describe explains the rounding, e.g. "rounded to the nearest 5 minutes"
+func (r Rules) Describe() string {
+	step := Format(int(r.RoundTo.Seconds()))
+	switch r.RoundMode {
+	case RoundUp:
+		return fmt.Sprintf("rounded up to %s", step)
+	case RoundDown:
+		return fmt.Sprintf("rounded down to %s", step)
+	default:
+		return fmt.Sprintf("rounded to the nearest %s", step)
+	}
 }
 
-// roundToNearest5 rounds a number to the nearest 5
-func roundToNearest5(minutes float64) float64 {
-	return math.Round(minutes/5) * 5
+// workday returns the length of a day, defaulting to 8 hours
+func (r Rules) workday() time.Duration {
+	if r.Workday <= 0 {
+		return DefaultRules.Workday
+	}
+	return r.Workday
+}
+
+// parseClock parses "9", "09:15" or "14:00" as a time on the day of now
+func parseClock(clock string, now time.Time) (time.Time, error) {
+	hourText, minuteText, _ := strings.Cut(clock, ":")
+	hour, err := strconv.Atoi(hourText)
+	if err != nil || hour > 23 {
+		return time.Time{}, fmt.Errorf("invalid clock time: %s", clock)
+	}
+	minute := 0
+	if minuteText != "" {
+		minute, err = strconv.Atoi(minuteText)
+		if err != nil || minute > 59 {
+			return time.Time{}, fmt.Errorf("invalid clock time: %s", clock)
+		}
+	}
+
+	year, month, day := now.Date()
+	return time.Date(year, month, day, hour, minute, 0, 0, now.Location()), nil
 }
 
 // Format formats seconds into a human-readable time string
@@ -2,6 +2,7 @@ package timeparse
 
 import (
 	"testing"
+	"time"
 )
 
 func TestParse(t *testing.T) {
@@ -16,11 +17,11 @@ func TestParse(t *testing.T) {
 		{"decimal hours", "2.5h", 9000, false},
 		{"minutes only", "150m", 9000, false},
 		{"hours only", "2h", 7200, false},
-		{"round up to 5", "2h 32m", 9000, false},      // 152m -> 150m (rounded down to nearest 5)
-		{"round down to 5", "2h 27m", 8700, false},    // 147m -> 145m
-		{"single minute rounds to 5", "1m", 0, false}, // 1m -> 0m (rounds down)
-		{"3 minutes rounds to 5", "3m", 300, false},   // 3m -> 5m
-		{"7 minutes rounds to 5", "7m", 300, false},   // 7m -> 5m
+		{"round up to 5", "2h 32m", 9000, false},     // 152m -> 150m (rounded down to nearest 5)
+		{"round down to 5", "2h 27m", 8700, false},   // 147m -> 145m
+		{"single minute rounds to 0", "1m", 0, true}, // 1m -> 0m is too short to log
+		{"3 minutes rounds to 5", "3m", 300, false},  // 3m -> 5m
+		{"7 minutes rounds to 5", "7m", 300, false},  // 7m -> 5m
 		{"case insensitive hours", "2H 30M", 9000, false},
 		{"case insensitive minutes", "150M", 9000, false},
 		{"hours with full word", "2 hours 30 minutes", 9000, false},
@@ -75,3 +76,124 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestRulesParseDays(t *testing.T) {
+	rules := Rules{RoundTo: 5 * time.Minute, RoundMode: RoundNearest, Workday: 7*time.Hour + 30*time.Minute}
+
+	tests := []struct {
+		input        string
+		expectedSecs int
+	}{
+		{"1d", 27000},
+		{"0.5d", 13500},
+		{"2 days", 54000},
+		{"1d 2h", 34200},
+		{"1 day 30m", 28800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := rules.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expectedSecs {
+				t.Errorf("expected %d seconds, got %d", tt.expectedSecs, result)
+			}
+		})
+	}
+
+	if result, _ := Parse("1d"); result != 8*3600 {
+		t.Errorf("default workday: expected %d seconds, got %d", 8*3600, result)
+	}
+}
+
+func TestRulesParseEstimate(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedSecs  int
+		expectedError bool
+	}{
+		{"2h", 7200, false},
+		{"0", 0, false},
+		{"0m", 0, false},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := DefaultRules.ParseEstimate(tt.input)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error for %q, got %d", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expectedSecs {
+				t.Errorf("ParseEstimate(%q) = %d, want %d", tt.input, got, tt.expectedSecs)
+			}
+		})
+	}
+}
+
+func TestParseEntry(t *testing.T) {
+	now := time.Date(2026, 3, 2, 16, 2, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name          string
+		input         string
+		expected      Entry
+		expectedError bool
+	}{
+		{"duration", "2h 30m", Entry{Seconds: 9000}, false},
+		{"workdays", "0.5d", Entry{Seconds: 14400}, false},
+		{"range", "09:15-11:40", Entry{Seconds: 8700, Started: at(9, 15)}, false},
+		{"range with spaces and short hours", "9 - 10:30", Entry{Seconds: 5400, Started: at(9, 0)}, false},
+		{"range is rounded", "09:02-09:59", Entry{Seconds: 3300, Started: at(9, 2)}, false},
+		{"since", "since 14:00", Entry{Seconds: 7200, Started: at(14, 0)}, false},
+		{"range rounding to nothing", "09:00-09:02", Entry{}, true},
+		{"since rounding to nothing", "since 16:01", Entry{}, true},
+		{"since is case insensitive", "Since 15", Entry{Seconds: 3600, Started: at(15, 0)}, false},
+		{"range ends before start", "11:00-10:00", Entry{}, true},
+		{"range with invalid clock", "09:75-10:00", Entry{}, true},
+		{"half a range", "09:15-", Entry{}, true},
+		{"since in the future", "since 17:00", Entry{}, true},
+		{"since without time", "since", Entry{}, true},
+		{"invalid duration", "soon", Entry{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := DefaultRules.ParseEntry(tt.input, now)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got %+v", entry)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entry.Seconds != tt.expected.Seconds || !entry.Started.Equal(tt.expected.Started) {
+				t.Errorf("got %+v, want %+v", entry, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRulesDescribe(t *testing.T) {
+	if got := DefaultRules.Describe(); got != "rounded to the nearest 5m" {
+		t.Errorf("Describe() = %q", got)
+	}
+	up := Rules{RoundTo: 15 * time.Minute, RoundMode: RoundUp}
+	if got := up.Describe(); got != "rounded up to 15m" {
+		t.Errorf("Describe() = %q", got)
+	}
+}
@@ -1,6 +1,9 @@
 package timeparse
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestValidate(t *testing.T) {
 	tests := []struct {
@@ -50,9 +53,34 @@ func TestRoundToNearest5(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			result := roundToNearest5(tt.input)
+			minutes := time.Duration(tt.input * float64(time.Minute))
+			result := float64(DefaultRules.Round(minutes)) / 60
 			if result != tt.expected {
-				t.Errorf("roundToNearest5(%v) = %v, want %v", tt.input, result, tt.expected)
+				t.Errorf("DefaultRules.Round(%vm) = %vm, want %vm", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRoundModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    Rules
+		input    time.Duration
+		expected int
+	}{
+		{"nearest 15", Rules{RoundTo: 15 * time.Minute, RoundMode: RoundNearest}, 52 * time.Minute, 45 * 60},
+		{"up 15", Rules{RoundTo: 15 * time.Minute, RoundMode: RoundUp}, 46 * time.Minute, 60 * 60},
+		{"up exact", Rules{RoundTo: 15 * time.Minute, RoundMode: RoundUp}, 45 * time.Minute, 45 * 60},
+		{"down 15", Rules{RoundTo: 15 * time.Minute, RoundMode: RoundDown}, 59 * time.Minute, 45 * 60},
+		{"one minute", Rules{RoundTo: time.Minute, RoundMode: RoundNearest}, 7*time.Minute + 10*time.Second, 7 * 60},
+		{"no granularity rounds to minutes", Rules{}, 7*time.Minute + 40*time.Second, 8 * 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.Round(tt.input); got != tt.expected {
+				t.Errorf("Round(%s) = %d, want %d", tt.input, got, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// browserCommands lists tools that open a URL in the default browser, per OS
+var browserCommands = map[string][]string{
+	"darwin":  {"open"},
+	"windows": {"rundll32", "url.dll,FileProtocolHandler"},
+	"linux":   {"xdg-open"},
+}
+
+// OpenBrowser opens url in the default browser without waiting for it to close
+func OpenBrowser(url string) error {
+	command, ok := browserCommands[runtime.GOOS]
+	if !ok {
+		return fmt.Errorf("opening a browser is not supported on %s", runtime.GOOS)
+	}
+	if _, err := exec.LookPath(command[0]); err != nil {
+		return fmt.Errorf("%s not found", command[0])
+	}
+
+	args := append(append([]string{}, command[1:]...), url)
+	cmd := exec.Command(command[0], args...) //nolint:gosec // G204: commands come from the fixed list above
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser with %s: %w", command[0], err)
+	}
+	go cmd.Wait() //nolint:errcheck // the browser's exit status doesn't matter
+	return nil
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommands lists clipboard tools to try per OS, in order of preference
+var clipboardCommands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip"}},
+	"linux": {
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	},
+}
+
+// CopyToClipboard copies text to the system clipboard using the platform's clipboard tool
+func CopyToClipboard(text string) error {
+	for _, command := range clipboardCommands[runtime.GOOS] {
+		if _, err := exec.LookPath(command[0]); err != nil {
+			continue
+		}
+
+		cmd := exec.Command(command[0], command[1:]...) //nolint:gosec // G204: commands come from the fixed list above
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy to clipboard with %s: %w", command[0], err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no clipboard tool found (install pbcopy, wl-copy, xclip or xsel)")
+}
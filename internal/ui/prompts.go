@@ -2,13 +2,19 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"tasklog/internal/jira"
 
 	"github.com/AlecAivazis/survey/v2"
 )
 
-// SelectTask presents the user with task selection options
+// createTaskOption is offered next to search and manual entry for work without a ticket
+const createTaskOption = "Create new task"
+
+// SelectTask presents the user with task selection options.
+// Choosing search returns a placeholder with only the search term as key;
+// choosing to create a task returns a placeholder without a key
 func SelectTask(inProgressIssues []jira.Issue) (*jira.Issue, error) {
 	if len(inProgressIssues) == 0 {
 		// No in-progress tasks, prompt for search or manual entry
@@ -20,7 +26,7 @@ func SelectTask(inProgressIssues []jira.Issue) (*jira.Issue, error) {
 	for _, issue := range inProgressIssues {
 		options = append(options, fmt.Sprintf("%s - %s", issue.Key, issue.Fields.Summary))
 	}
-	options = append(options, "Search for a task", "Enter task key manually")
+	options = append(options, "Search for a task", "Enter task key manually", createTaskOption)
 
 	var selected string
 	prompt := &survey.Select{
@@ -40,6 +46,9 @@ func SelectTask(inProgressIssues []jira.Issue) (*jira.Issue, error) {
 	if selected == "Enter task key manually" {
 		return promptManualTaskKey()
 	}
+	if selected == createTaskOption {
+		return &jira.Issue{}, nil
+	}
 
 	// Find the selected issue
 	for _, issue := range inProgressIssues {
@@ -53,7 +62,7 @@ func SelectTask(inProgressIssues []jira.Issue) (*jira.Issue, error) {
 
 // selectTaskWithoutInProgress handles task selection when no in-progress tasks exist
 func selectTaskWithoutInProgress() (*jira.Issue, error) {
-	options := []string{"Search for a task", "Enter task key manually"}
+	options := []string{"Search for a task", "Enter task key manually", createTaskOption}
 
 	var selected string
 	prompt := &survey.Select{
@@ -68,6 +77,9 @@ func selectTaskWithoutInProgress() (*jira.Issue, error) {
 	if selected == "Search for a task" {
 		return promptTaskSearch()
 	}
+	if selected == createTaskOption {
+		return &jira.Issue{}, nil
+	}
 	return promptManualTaskKey()
 }
 
@@ -132,12 +144,41 @@ func SelectFromSearchResults(issues []jira.Issue) (*jira.Issue, error) {
 	return nil, fmt.Errorf("task not found")
 }
 
-// PromptTimeSpent prompts the user for time spent
-func PromptTimeSpent() (string, error) {
+// SelectTransition asks whether to also move the issue through a transition; nil means keep the current status
+func SelectTransition(currentStatus string, transitions []jira.Transition) (*jira.Transition, error) {
+	keep := "Keep as is"
+	if currentStatus != "" {
+		keep = fmt.Sprintf("Keep in %s", currentStatus)
+	}
+
+	options := []string{keep}
+	for _, transition := range transitions {
+		options = append(options, transition.Label())
+	}
+
+	var selected int
+	prompt := &survey.Select{
+		Message:  "Also transition to…",
+		Options:  options,
+		PageSize: 10,
+	}
+
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+
+	if selected == 0 {
+		return nil, nil
+	}
+	return &transitions[selected-1], nil
+}
+
+// PromptTimeSpent prompts the user for time spent; rounding describes how durations are rounded
+func PromptTimeSpent(rounding string) (string, error) {
 	var timeSpent string
 	prompt := &survey.Input{
-		Message: "Enter time spent (e.g., 2h 30m, 2.5h, 150m):",
-		Help:    "Formats: 2h 30m, 2.5h, 150m (will be rounded to nearest 5 minutes)",
+		Message: "Enter time spent (e.g., 2h 30m, 0.5d, 09:15-11:40, since 14:00):",
+		Help:    fmt.Sprintf("Formats: 2h 30m, 2.5h, 150m, 1d (one workday), 09:15-11:40 (sets the start), since 14:00 (until now); %s", rounding),
 	}
 
 	if err := survey.AskOne(prompt, &timeSpent, survey.WithValidator(survey.Required)); err != nil {
@@ -196,6 +237,22 @@ func PromptComment() (string, error) {
 	return comment, nil
 }
 
+// PromptCommentEditor opens $EDITOR (or $VISUAL) for an optional multi-line markdown comment
+func PromptCommentEditor() (string, error) {
+	var comment string
+	prompt := &survey.Editor{
+		Message:  "Enter a comment (optional, markdown):",
+		Help:     "Opens $EDITOR. Markdown lists, links, **bold** and `code` are kept in Jira; issue keys and URLs are linked",
+		FileName: "tasklog-comment*.md",
+	}
+
+	if err := survey.AskOne(prompt, &comment); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(comment), nil
+}
+
 // Confirm asks the user for confirmation
 func Confirm(message string) (bool, error) {
 	var confirmed bool
@@ -210,3 +267,71 @@ func Confirm(message string) (bool, error) {
 
 	return confirmed, nil
 }
+
+// PromptInput prompts the user for a line of text with an optional default value
+func PromptInput(message, defaultValue string, required bool) (string, error) {
+	var value string
+	prompt := &survey.Input{
+		Message: message,
+		Default: defaultValue,
+	}
+
+	var opts []survey.AskOpt
+	if required {
+		opts = append(opts, survey.WithValidator(survey.Required))
+	}
+
+	if err := survey.AskOne(prompt, &value, opts...); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(value), nil
+}
+
+// PromptSecret prompts the user for a secret without echoing it
+func PromptSecret(message, help string) (string, error) {
+	var value string
+	prompt := &survey.Password{
+		Message: message,
+		Help:    help,
+	}
+
+	if err := survey.AskOne(prompt, &value, survey.WithValidator(survey.Required)); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(value), nil
+}
+
+// Select prompts the user to pick one option
+func Select(message string, options []string) (string, error) {
+	var selected string
+	prompt := &survey.Select{
+		Message:  message,
+		Options:  options,
+		PageSize: 10,
+	}
+
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return "", err
+	}
+
+	return selected, nil
+}
+
+// MultiSelect prompts the user to pick any number of options
+func MultiSelect(message string, options, defaults []string) ([]string, error) {
+	var selected []string
+	prompt := &survey.MultiSelect{
+		Message:  message,
+		Options:  options,
+		Default:  defaults,
+		PageSize: 15,
+	}
+
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
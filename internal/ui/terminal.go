@@ -0,0 +1,12 @@
+package ui
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// IsInteractive reports whether stdin is a terminal that prompts can read from
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) //nolint:gosec // G115: file descriptors fit in int
+}